@@ -6,7 +6,7 @@ package cli
 import (
 	"strings"
 
-	"github.com/mitchs-dev/worklog/internal/logManager"
+	"github.com/mitchs-dev/worklog"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -16,7 +16,9 @@ var addCli = &cobra.Command{
 	Use:     "add",
 	Aliases: []string{"a"},
 	Short:   "Add a new entry to your worklog",
-	Long:    `This command will add a new entry to your worklog and then display the ID associated with the entry.`,
+	Long: `This command will add a new entry to your worklog and then display the ID associated with the entry.
+
+Tags and a project can be added with --tag and --project, or inline in the entry (I.e "Fixed the invoice export #bug @billing").`,
 	Run: func(Cli *cobra.Command, args []string) {
 
 		log.Debug("Running the add command")
@@ -31,31 +33,41 @@ var addCli = &cobra.Command{
 
 		logEntry := strings.Join(logEntryArgs, " ")
 
-		log.Debug("Calling logManager.Action(\"add\")")
-		addedEntry, logIds := logManager.Action("add", logEntry, "", "")
-
-		if len(addedEntry.Entries) == 1 && len(logIds) == 1 {
-
-			logEntry := addedEntry.Entries[logIds[0]]
+		tags, err := Cli.Flags().GetStringSlice("tag")
+		if err != nil {
+			log.Fatal("Failed to get tag flag")
+		}
 
-			if logEntry.Status == logManager.EntryStatusAdded {
+		project, err := Cli.Flags().GetString("project")
+		if err != nil {
+			log.Fatal("Failed to get project flag")
+		}
 
-				log.Debug("Entry added successfully")
-				log.Info("Entry ID: " + logIds[0])
+		addedEntry, err := store.AddWithMetadata(logEntry, worklog.Metadata{
+			Tags:    tags,
+			Project: project,
+		})
+		if err != nil {
+			log.Fatal("Failed to add: ", err)
+		}
 
-			} else {
-				log.Fatal("Unexpected status: ", logEntry.Status)
-			}
-		} else {
-			log.Error("Failed to add")
+		if addedEntry.Status != worklog.EntryStatusAdded {
+			log.Fatal("Unexpected status: ", addedEntry.Status)
 		}
 
+		log.Debug("Entry added successfully")
+		log.Info("Entry ID: " + addedEntry.ID)
+
 	},
 }
 
 func init() {
 	rootCli.AddCommand(addCli)
 
+	addCli.Flags().StringSlice("tag", nil, "Tag the entry (Can be repeated, I.e --tag infra --tag oncall)")
+	addCli.Flags().String("project", "", "The project the entry belongs to")
+	addCli.Flags().StringVar(&workdayOverride, "override", "", "Log the entry outside of the workday and record why (I.e --override \"On call\")")
+
 	// Here you will define your flags and configuration settings.
 
 	// Cobra supports Persistent Flags which will work for this command
@@ -0,0 +1,101 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// editCli represents the edit command
+var editCli = &cobra.Command{
+	Use:     "edit <id> [entry]",
+	Aliases: []string{"ed"},
+	Short:   "Edit an entry in your worklog",
+	Long: `This command will edit an entry in your worklog.
+
+If no new entry is provided, the current entry is opened in your $EDITOR.
+
+The original entry is never changed. Instead, each edit is kept as a revision which can be seen with: list --history`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the edit command")
+
+		if len(args) == 0 {
+			log.Fatal("Expected a log id (I.e 0123-4)")
+		}
+
+		logId := args[0]
+		logEntry := strings.Join(args[1:], " ")
+
+		if logEntry == "" {
+			log.Debug("No entry provided, opening the editor")
+
+			currentEntry, err := store.Get(logId)
+			if err != nil {
+				log.Fatal("Failed to get log id (", logId, "): ", err)
+			}
+
+			logEntry = editInEditor(currentEntry.Message)
+			if logEntry == "" {
+				log.Fatal("Empty entry, nothing was changed")
+			}
+		}
+
+		editedEntry, err := store.Edit(logId, logEntry)
+		if err != nil {
+			log.Fatal("Failed to edit: ", err)
+		}
+
+		log.Info("Entry ID: " + editedEntry.ID + " edited (Revision " + fmt.Sprint(len(editedEntry.Revisions)-1) + ")")
+
+	},
+}
+
+// editInEditor opens the message in the user's $EDITOR (Defaults to vi) and returns the edited message
+func editInEditor(message string) string {
+
+	editor := strings.Fields(os.Getenv("EDITOR"))
+	if len(editor) == 0 {
+		editor = []string{"vi"}
+	}
+
+	tmpFile, err := os.CreateTemp("", "worklog-edit-*.txt")
+	if err != nil {
+		log.Fatal("Failed to create temporary file: ", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	_, err = tmpFile.WriteString(message + "\n")
+	if err != nil {
+		log.Fatal("Failed to write temporary file: ", err)
+	}
+	tmpFile.Close()
+
+	cmd := exec.Command(editor[0], append(editor[1:], tmpFile.Name())...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	err = cmd.Run()
+	if err != nil {
+		log.Fatal("Failed to run editor (", strings.Join(editor, " "), "): ", err)
+	}
+
+	editedMessage, err := os.ReadFile(tmpFile.Name())
+	if err != nil {
+		log.Fatal("Failed to read temporary file: ", err)
+	}
+
+	// Entries are a single line, so fold any new lines the editor added
+	return strings.Join(strings.Fields(string(editedMessage)), " ")
+}
+
+func init() {
+	rootCli.AddCommand(editCli)
+}
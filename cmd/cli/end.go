@@ -0,0 +1,23 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// endCli represents the end command
+var endCli = &cobra.Command{
+	Use:     "end <id>",
+	Aliases: []string{"en"},
+	Short:   "End tracking time on an entry in your worklog",
+	Long:    `This command will stop the clock on an entry in your worklog and mark it as completed. Completed entries can't be started, paused or resumed again.`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("end", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(endCli)
+}
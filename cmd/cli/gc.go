@@ -0,0 +1,56 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// gcCli represents the gc command
+var gcCli = &cobra.Command{
+	Use:   "gc",
+	Short: "Remove empty week files from your worklog",
+	Long: `This command will remove the week files without any entries from your worklog.
+
+Older versions of worklog created an empty (or {"Weeks":{}}) week file for every week that was listed, which then showed up as changes in worklog sync.
+Week files which can't be parsed are never removed.`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the gc command")
+
+		dryRun, err := Cli.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal("Failed to get dry-run flag")
+		}
+
+		removedWeeks, err := store.GarbageCollect(dryRun)
+		if err != nil {
+			log.Fatal("Failed to remove empty weeks: ", err)
+		}
+
+		if len(removedWeeks) == 0 {
+			log.Info("No empty weeks found")
+			return
+		}
+
+		for _, week := range removedWeeks {
+			fmt.Println(week)
+		}
+
+		if dryRun {
+			log.Info("Would remove " + fmt.Sprint(len(removedWeeks)) + " empty weeks")
+		} else {
+			log.Info("Removed " + fmt.Sprint(len(removedWeeks)) + " empty weeks")
+		}
+	},
+}
+
+func init() {
+	rootCli.AddCommand(gcCli)
+
+	gcCli.Flags().BoolP("dry-run", "", false, "Only show the empty weeks which would be removed")
+}
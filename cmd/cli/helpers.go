@@ -0,0 +1,37 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/mitchs-dev/worklog"
+	log "github.com/sirupsen/logrus"
+)
+
+// runEntryAction runs an action (I.e start, pause, remove) against a single log id and reports the new status
+func runEntryAction(action string, args []string) {
+
+	log.Debug("Running the " + action + " command")
+
+	if len(args) != 1 {
+		log.Fatal("Expected exactly one log id (I.e 0123-4)")
+	}
+
+	logId := args[0]
+
+	entryActions := map[string]func(string) (worklog.Entry, error){
+		"remove":  store.Remove,
+		"restore": store.Restore,
+		"start":   store.Start,
+		"pause":   store.Pause,
+		"resume":  store.Resume,
+		"end":     store.End,
+	}
+
+	logEntry, err := entryActions[action](logId)
+	if err != nil {
+		log.Fatal("Failed to ", action, ": ", err)
+	}
+
+	log.Info("Entry ID: " + logEntry.ID + " is now " + logEntry.Status + " (" + logEntry.Worked + " worked)")
+}
@@ -4,11 +4,13 @@ Copyright © 2025 @mitchs-dev <github@mitchs.dev>
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
-	"github.com/mitchs-dev/worklog/internal/configuration"
-	"github.com/mitchs-dev/worklog/internal/logManager"
+	"github.com/mitchs-dev/worklog"
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/outputManager"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -36,7 +38,16 @@ Available Periods:
   Extended Periods:
     • month      - Last 30 days
     • quarter    - Last 90 days
-    • year       - Last 365 days`,
+    • year       - Last 365 days
+
+Instead of a period, you can also list a specific date range or calendar week, month or quarter:
+
+    --from 2026-03-01 --to 2026-03-31   - Every day in the range (--to defaults to today)
+    --week 2026-W12                      - ISO week 12 of 2026 (Monday to Sunday)
+    --month 2026-03                      - March 2026
+    --quarter 2026-Q1                    - January to March 2026
+
+Entries can be filtered by their tags and project with --tag and --project (I.e --tag infra --project billing).`,
 	Run: func(Cli *cobra.Command, args []string) {
 
 		period, err := Cli.Flags().GetString("period")
@@ -44,65 +55,190 @@ Available Periods:
 			log.Fatal("Failed to get period flag")
 		}
 
+		period, err = rangePeriod(Cli, period)
+		if err != nil {
+			log.Fatal(err)
+		}
+
 		log.Debug("Period: ", period)
 
+		includeRemoved, err := Cli.Flags().GetBool("include-removed")
+		if err != nil {
+			log.Fatal("Failed to get include-removed flag")
+		}
+
+		showHistory, err := Cli.Flags().GetBool("history")
+		if err != nil {
+			log.Fatal("Failed to get history flag")
+		}
+
 		log.Debug("Running the list command")
-		listEntries, logIds := logManager.Action("list", "", "", period)
+		entries, err := store.List(period)
+		if err != nil {
+			log.Fatal("Failed to list: ", err)
+		}
+
+		// Hide removed entries unless they were asked for
+		if !includeRemoved {
+			var visibleEntries []worklog.Entry
+			for _, entry := range entries {
+				if entry.Status == worklog.EntryStatusRemoved {
+					continue
+				}
+				visibleEntries = append(visibleEntries, entry)
+			}
+			entries = visibleEntries
+		}
+
+		tags, err := Cli.Flags().GetStringSlice("tag")
+		if err != nil {
+			log.Fatal("Failed to get tag flag")
+		}
+
+		project, err := Cli.Flags().GetString("project")
+		if err != nil {
+			log.Fatal("Failed to get project flag")
+		}
+
+		if len(tags) > 0 || project != "" {
+			entries = worklog.FilterEntries(entries, tags, project)
+		}
+
+		sortOrder, err := Cli.Flags().GetString("sort")
+		if err != nil {
+			log.Fatal("Failed to get sort flag")
+		}
+
+		reverse, err := Cli.Flags().GetBool("reverse")
+		if err != nil {
+			log.Fatal("Failed to get reverse flag")
+		}
+
+		entries, err = worklog.SortEntries(entries, sortOrder, reverse)
+		if err != nil {
+			log.Fatal(err)
+		}
 
 		outputFormat, err := Cli.Flags().GetString("output")
 		if err != nil {
 			log.Fatal("Failed to get output flag")
 		}
 
-		var formatFound bool
-		for _, format := range configuration.AllowedOutputFormats {
-			if format == outputFormat {
-				log.Debug("Output format: ", outputFormat)
-				formatFound = true
-				break
-			}
+		templateFile, err := Cli.Flags().GetString("template-file")
+		if err != nil {
+			log.Fatal("Failed to get template-file flag")
 		}
 
-		if !formatFound {
-			log.Fatal("Invalid output format: ", outputFormat)
+		log.Debug("Output format: ", outputFormat)
+
+		listReturn := outputManager.List{
+			Period:  period,
+			Entries: make([]outputManager.Entry, 0, len(entries)),
+		}
+		for _, entry := range entries {
+			listReturn.Entries = append(listReturn.Entries, newListEntry(entry, showHistory))
 		}
 
-		var stdReturn string
+		stdReturn, err := outputManager.Format(outputFormat, listReturn, outputManager.Options{
+			TemplateFile: templateFile,
+		})
+		if err != nil {
+			log.Fatal("Failed to format entries: ", err)
+		}
 
-		if len(listEntries.Entries) > 0 {
-			switch outputFormat {
-			case "json":
-				stdReturn = "{\"period\":\"" + period + "\",\"worklog\":{"
-			default:
-				stdReturn = "Period: " + period
-				stdReturn += "\nWorklog:\n"
-			}
-			for _, logId := range logIds {
-				logEntry := listEntries.Entries[logId]
-				switch outputFormat {
-				case "json":
-					stdReturn += fmt.Sprintf("\"%s\": \"%s\",", logId, logEntry.Message)
-				case "yaml":
-					stdReturn += fmt.Sprintf("  %s: \"%s\"\n", logId, logEntry.Message)
-				case "text":
-					stdReturn += fmt.Sprintf("- [%s] %s\n", logId, logEntry.Message)
-				}
-			}
-			switch outputFormat {
-			case "json":
-				stdReturn = stdReturn[:len(stdReturn)-1] + "}}"
-			}
-			fmt.Println(strings.TrimSpace(stdReturn))
-		} else {
+		if stdReturn == "" {
 			log.Info("No entries found")
+			return
 		}
 
+		fmt.Println(stdReturn)
+
 	},
 }
 
+// newListEntry converts a log entry to an entry in the list output
+func newListEntry(logEntry worklog.Entry, showHistory bool) outputManager.Entry {
+
+	entry := outputManager.Entry{
+		ID:             logEntry.ID,
+		Date:           logEntry.Date,
+		Week:           logEntry.Week,
+		Status:         logEntry.Status,
+		Time:           logEntry.Time,
+		Elapsed:        logEntry.Worked,
+		ElapsedSeconds: logEntry.Elapsed,
+		Message:        logEntry.Message,
+		Tags:           logEntry.Tags,
+		Project:        logEntry.Project,
+	}
+
+	if logEntry.Started != 0 {
+		started := calendarManager.FromEpoch(logEntry.Started)
+		entry.Started = &started
+	}
+
+	if showHistory {
+		for _, revision := range logEntry.Revisions {
+			entry.History = append(entry.History, outputManager.Revision{
+				Time:    calendarManager.FromEpoch(revision.Time),
+				Message: revision.Message,
+			})
+		}
+	}
+
+	return entry
+}
+
+// rangePeriod replaces the period with a date range or calendar selector if one of the range flags was provided
+func rangePeriod(Cli *cobra.Command, period string) (string, error) {
+
+	var selectedFlags []string
+	for _, flag := range []string{"from", "week", "month", "quarter"} {
+		if Cli.Flags().Changed(flag) {
+			selectedFlags = append(selectedFlags, "--"+flag)
+		}
+	}
+
+	if Cli.Flags().Changed("to") && !Cli.Flags().Changed("from") {
+		return "", errors.New("--to requires --from")
+	}
+
+	if len(selectedFlags) == 0 {
+		return period, nil
+	}
+
+	if len(selectedFlags) > 1 {
+		return "", errors.New("only one of " + strings.Join(selectedFlags, ", ") + " can be used at a time")
+	}
+
+	if Cli.Flags().Changed("period") {
+		return "", errors.New("--period can't be used with " + selectedFlags[0])
+	}
+
+	if selectedFlags[0] == "--from" {
+		from, _ := Cli.Flags().GetString("from")
+		to, _ := Cli.Flags().GetString("to")
+		return calendarManager.DateRangePeriod(from, to), nil
+	}
+
+	return Cli.Flags().GetString(strings.TrimPrefix(selectedFlags[0], "--"))
+}
+
 func init() {
 	rootCli.AddCommand(listCli)
 
 	listCli.Flags().StringP("period", "p", "today", "The period to list entries for")
-	listCli.Flags().StringP("output", "o", "text", "The output format (text, json, yaml)")
+	listCli.Flags().StringP("output", "o", "text", "The output format ("+strings.Join(outputManager.Formats(), ", ")+")")
+	listCli.Flags().StringP("sort", "", worklog.SortOrders[0], "The order to list entries in ("+strings.Join(worklog.SortOrders, ", ")+")")
+	listCli.Flags().BoolP("reverse", "", false, "List entries in reverse order")
+	listCli.Flags().StringP("template-file", "", "", "The Go template file used by the template output format")
+	listCli.Flags().StringP("from", "", "", "List entries from this date (YYYY-MM-DD)")
+	listCli.Flags().StringP("to", "", "", "List entries up to this date (YYYY-MM-DD), used with --from")
+	listCli.Flags().StringP("week", "", "", "List entries for an ISO week (YYYY-Www)")
+	listCli.Flags().StringP("month", "", "", "List entries for a month (YYYY-MM)")
+	listCli.Flags().StringP("quarter", "", "", "List entries for a quarter (YYYY-Qn)")
+	listCli.Flags().StringSliceP("tag", "", nil, "Only list entries with the tag (Can be repeated, entries must have every tag)")
+	listCli.Flags().StringP("project", "", "", "Only list entries for the project")
+	listCli.Flags().BoolP("include-removed", "", false, "Include entries that have been removed")
+	listCli.Flags().BoolP("history", "", false, "Show the revision history of edited entries")
 }
@@ -0,0 +1,62 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"os"
+	"sort"
+
+	"github.com/mitchs-dev/worklog"
+	"github.com/mitchs-dev/worklog/internal/syncManager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// mergeDriverCli represents the merge-driver command
+var mergeDriverCli = &cobra.Command{
+	Use:   "merge-driver <base> <ours> <theirs> [path]",
+	Short: "Merge two versions of a week file (Used by git when syncing)",
+	Long: `This command is used by git to merge a week file which was changed on two machines (%O %A %B %P).
+
+The entries of both versions are kept, and an entry which was added on both machines with the same ID is given the next free ID.
+The merged week is written to the <ours> file. worklog sync registers it for you through .gitattributes and .git/config.
+The renumbered entries are recorded in .git with the path of the week file, so that worklog sync can show them.`,
+	Args:   cobra.RangeArgs(3, 4),
+	Hidden: true,
+	// git runs the merge driver inside the repository, which doesn't need the configuration
+	// The logs go to stderr, since git shows what the merge driver prints
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		setupLogging(os.Stderr)
+	},
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the merge-driver command")
+
+		renumbered, err := worklog.MergeWeekFiles(args[0], args[1], args[2])
+		if err != nil {
+			log.Fatal("Failed to merge the week file: ", err)
+		}
+
+		var oldIds []string
+		for oldId := range renumbered {
+			oldIds = append(oldIds, oldId)
+		}
+		sort.Strings(oldIds)
+
+		for _, oldId := range oldIds {
+			log.Warn("Entry " + oldId + " was added on both machines, their entry is now " + renumbered[oldId])
+		}
+
+		// A driver which was registered by an older version isn't given the path
+		if len(args) == 4 {
+			if err := syncManager.RecordRenumbered(args[3], renumbered); err != nil {
+				log.Warn("Failed to record the renumbered entries: ", err)
+			}
+		}
+	},
+}
+
+func init() {
+	rootCli.AddCommand(mergeDriverCli)
+}
@@ -0,0 +1,23 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// pauseCli represents the pause command
+var pauseCli = &cobra.Command{
+	Use:     "pause <id>",
+	Aliases: []string{"pa"},
+	Short:   "Pause tracking time on an entry in your worklog",
+	Long:    `This command will pause the clock on an entry in your worklog. The time worked so far is added to the total for the entry.`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("pause", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(pauseCli)
+}
@@ -0,0 +1,36 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// reindexCli represents the reindex command
+var reindexCli = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild the index of your worklog",
+	Long: `This command will rebuild the index of your worklog from the week files.
+
+The index is kept up to date whenever an entry changes and any week that changed outside of worklog (I.e after a sync) is read from its week file instead.
+So this is only needed if the index was deleted or to speed up the first list or search after a sync.`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the reindex command")
+
+		weeks, entries, err := store.Reindex()
+		if err != nil {
+			log.Fatal("Failed to reindex: ", err)
+		}
+
+		log.Info("Indexed " + fmt.Sprint(entries) + " entries from " + fmt.Sprint(weeks) + " weeks")
+	},
+}
+
+func init() {
+	rootCli.AddCommand(reindexCli)
+}
@@ -0,0 +1,25 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// removeCli represents the remove command
+var removeCli = &cobra.Command{
+	Use:     "remove <id>",
+	Aliases: []string{"rm"},
+	Short:   "Remove an entry from your worklog",
+	Long: `This command will remove an entry from your worklog.
+
+The entry isn't deleted, it is only hidden from the list command (Use --include-removed to show it). This means that it can be restored with the restore command within the restore window (settings.logs.restoreWindow).`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("remove", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(removeCli)
+}
@@ -0,0 +1,70 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/reportManager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// reportCli represents the report command
+var reportCli = &cobra.Command{
+	Use:     "report",
+	Aliases: []string{"rp"},
+	Short:   "Summarize your worklog for a period",
+	Long: `This command will summarize your worklog for a period (By default, the last 7 days).
+
+The report shows the number of entries and the time worked on each day, by tag and by project,
+the busiest days, streaks of logged work days and a day-by-day breakdown of the entries.
+
+It accepts the same periods as list (I.e --period week, --period month, --period quarter), as well as
+a date range or calendar week, month or quarter (I.e --week 2026-W12, --month 2026-03, --quarter 2026-Q1).`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the report command")
+
+		period, err := Cli.Flags().GetString("period")
+		if err != nil {
+			log.Fatal("Failed to get period flag")
+		}
+
+		period, err = rangePeriod(Cli, period)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		outputFormat, err := Cli.Flags().GetString("output")
+		if err != nil {
+			log.Fatal("Failed to get output flag")
+		}
+
+		report, err := store.Report(period)
+		if err != nil {
+			log.Fatal("Failed to build report: ", err)
+		}
+
+		stdReturn, err := reportManager.Format(outputFormat, report)
+		if err != nil {
+			log.Fatal("Failed to format report: ", err)
+		}
+
+		fmt.Println(stdReturn)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(reportCli)
+
+	reportCli.Flags().StringP("period", "p", "week", "The period to report on (I.e week, month, quarter)")
+	reportCli.Flags().StringP("output", "o", "text", "The output format ("+strings.Join(reportManager.Formats, ", ")+")")
+	reportCli.Flags().StringP("from", "", "", "Report from this date (YYYY-MM-DD)")
+	reportCli.Flags().StringP("to", "", "", "Report up to this date (YYYY-MM-DD), used with --from")
+	reportCli.Flags().StringP("week", "", "", "Report on an ISO week (YYYY-Www)")
+	reportCli.Flags().StringP("month", "", "", "Report on a month (YYYY-MM)")
+	reportCli.Flags().StringP("quarter", "", "", "Report on a quarter (YYYY-Qn)")
+}
@@ -0,0 +1,23 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// restoreCli represents the restore command
+var restoreCli = &cobra.Command{
+	Use:     "restore <id>",
+	Aliases: []string{"rt"},
+	Short:   "Restore a removed entry in your worklog",
+	Long:    `This command will restore an entry that was removed from your worklog, as long as it was removed within the restore window (settings.logs.restoreWindow).`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("restore", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(restoreCli)
+}
@@ -0,0 +1,25 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// resumeCli represents the resume command
+var resumeCli = &cobra.Command{
+	Use:     "resume <id>",
+	Aliases: []string{"rs"},
+	Short:   "Resume tracking time on a paused entry in your worklog",
+	Long:    `This command will resume the clock on a paused entry in your worklog.`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("resume", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(resumeCli)
+
+	resumeCli.Flags().StringVar(&workdayOverride, "override", "", "Resume the entry outside of the workday and record why (I.e --override \"On call\")")
+}
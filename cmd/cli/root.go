@@ -4,22 +4,56 @@ Copyright © 2025 @mitchs-dev <github@mitch.dev>
 package cli
 
 import (
+	"io"
 	"os"
 
+	"github.com/mitchs-dev/library-go/loggingFormatter"
+	"github.com/mitchs-dev/worklog"
 	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+var (
+	// store is the worklog store used by the commands (Opened before every command runs)
+	store *worklog.Store
+
+	// workdayOverride is the reason for logging work outside of the workday (Set with --override)
+	workdayOverride string
+
+	// enableDebugMode enables the debug logs (Set with --debug)
+	enableDebugMode bool
+)
+
 // rootCli represents the base command when called without any subcommands
 var rootCli = &cobra.Command{
 	Use:   "worklog",
 	Short: "Worklog is a CLI tool to help you track your work",
 	Long:  `Worklog is a CLI tool to help you track your work.`,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		configuration.ConfigInit()
+		setupLogging(os.Stdout)
+		var err error
+		store, err = worklog.Open(configuration.ConfigurationPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		store.Override = workdayOverride
 	},
 }
 
+// setupLogging sets the output, format and level of the logs
+// The configuration doesn't do this, so that programs using worklog as a library keep their own logging
+func setupLogging(output io.Writer) {
+	log.SetOutput(output)
+	log.SetFormatter(&loggingFormatter.Formatter{})
+	if enableDebugMode {
+		log.SetLevel(log.DebugLevel)
+		log.Debug("Debug mode enabled")
+	} else {
+		log.SetLevel(log.InfoLevel)
+	}
+}
+
 func Execute() {
 
 	err := rootCli.Execute()
@@ -31,7 +65,7 @@ func Execute() {
 
 func init() {
 	// Add the flags to the root command
-	rootCli.PersistentFlags().BoolVar(&configuration.EnableDebugMode, "debug", false, "Enable debug mode")
+	rootCli.PersistentFlags().BoolVar(&enableDebugMode, "debug", false, "Enable debug mode")
 	rootCli.PersistentFlags().StringVarP(&configuration.ConfigurationPath, "config", "c", "", "Path to the configuration file")
 
 }
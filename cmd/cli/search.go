@@ -0,0 +1,162 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/mitchs-dev/worklog"
+	"github.com/mitchs-dev/worklog/internal/outputManager"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// searchCli represents the search command
+var searchCli = &cobra.Command{
+	Use:     "search <query>",
+	Aliases: []string{"se"},
+	Short:   "Search the entire history of your worklog",
+	Long: `This command will search the messages of every entry in your worklog, no matter how old it is.
+
+By default, entries match when they contain every word of the query (In any order and ignoring case).
+Use --phrase to match the words as a phrase, or --regex to match a regular expression.
+
+The results are grouped by date with the most recent first (Use --reverse for the oldest first).
+
+Examples:
+
+    worklog search invoice export
+    worklog search --phrase "code review"
+    worklog search --regex "PR #\d+" --from 2025-01-01 --to 2025-06-30
+    worklog search outage -o markdown`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the search command")
+
+		if len(args) == 0 {
+			log.Fatal("Expected a query")
+		}
+
+		query := worklog.SearchQuery{
+			Text: strings.Join(args, " "),
+		}
+
+		phrase, err := Cli.Flags().GetBool("phrase")
+		if err != nil {
+			log.Fatal("Failed to get phrase flag")
+		}
+
+		regex, err := Cli.Flags().GetBool("regex")
+		if err != nil {
+			log.Fatal("Failed to get regex flag")
+		}
+
+		switch {
+		case phrase && regex:
+			log.Fatal("Only one of --phrase, --regex can be used at a time")
+		case phrase:
+			query.Mode = "phrase"
+		case regex:
+			query.Mode = "regex"
+		}
+
+		query.CaseSensitive, err = Cli.Flags().GetBool("case-sensitive")
+		if err != nil {
+			log.Fatal("Failed to get case-sensitive flag")
+		}
+
+		query.From, err = Cli.Flags().GetString("from")
+		if err != nil {
+			log.Fatal("Failed to get from flag")
+		}
+
+		query.To, err = Cli.Flags().GetString("to")
+		if err != nil {
+			log.Fatal("Failed to get to flag")
+		}
+
+		includeRemoved, err := Cli.Flags().GetBool("include-removed")
+		if err != nil {
+			log.Fatal("Failed to get include-removed flag")
+		}
+
+		reverse, err := Cli.Flags().GetBool("reverse")
+		if err != nil {
+			log.Fatal("Failed to get reverse flag")
+		}
+
+		outputFormat, err := Cli.Flags().GetString("output")
+		if err != nil {
+			log.Fatal("Failed to get output flag")
+		}
+
+		templateFile, err := Cli.Flags().GetString("template-file")
+		if err != nil {
+			log.Fatal("Failed to get template-file flag")
+		}
+
+		entries, err := store.Search(query)
+		if err != nil {
+			log.Fatal("Failed to search: ", err)
+		}
+
+		// The entries are oldest first, so the most recent days are first unless --reverse was used
+		// The entries of a day stay in the order they were added
+		if !reverse {
+			slices.SortStableFunc(entries, func(a, b worklog.Entry) int {
+				return strings.Compare(b.Date, a.Date)
+			})
+		}
+
+		searchReturn := outputManager.List{
+			Period:  searchPeriod(query),
+			Entries: make([]outputManager.Entry, 0, len(entries)),
+		}
+		for _, entry := range entries {
+			if entry.Status == worklog.EntryStatusRemoved && !includeRemoved {
+				continue
+			}
+			searchReturn.Entries = append(searchReturn.Entries, newListEntry(entry, false))
+		}
+
+		stdReturn, err := outputManager.Format(outputFormat, searchReturn, outputManager.Options{
+			TemplateFile: templateFile,
+		})
+		if err != nil {
+			log.Fatal("Failed to format entries: ", err)
+		}
+
+		if stdReturn == "" {
+			log.Info("No entries found")
+			return
+		}
+
+		fmt.Println(stdReturn)
+
+	},
+}
+
+// searchPeriod describes the date bounds of the search (I.e all, 2025-01-01..2025-06-30)
+func searchPeriod(query worklog.SearchQuery) string {
+	if query.From == "" && query.To == "" {
+		return "all"
+	}
+	return query.From + ".." + query.To
+}
+
+func init() {
+	rootCli.AddCommand(searchCli)
+
+	searchCli.Flags().BoolP("phrase", "", false, "Match the words of the query as a phrase")
+	searchCli.Flags().BoolP("regex", "", false, "Match the query as a regular expression")
+	searchCli.Flags().BoolP("case-sensitive", "", false, "Match the case of the query")
+	searchCli.Flags().StringP("from", "", "", "Only search entries from this date (YYYY-MM-DD)")
+	searchCli.Flags().StringP("to", "", "", "Only search entries up to this date (YYYY-MM-DD)")
+	searchCli.Flags().BoolP("include-removed", "", false, "Include entries that have been removed")
+	searchCli.Flags().BoolP("reverse", "", false, "List the oldest entries first")
+	searchCli.Flags().StringP("output", "o", "text", "The output format ("+strings.Join(outputManager.Formats(), ", ")+")")
+	searchCli.Flags().StringP("template-file", "", "", "The Go template file used by the template output format")
+}
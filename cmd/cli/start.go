@@ -0,0 +1,28 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+package cli
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// startCli represents the start command
+var startCli = &cobra.Command{
+	Use:     "start <id>",
+	Aliases: []string{"st"},
+	Short:   "Start tracking time on an entry in your worklog",
+	Long: `This command will start the clock on an entry in your worklog which isn't tracked yet.
+
+Entries are started when they are added, so this is only needed for entries which were logged before worklog tracked time.
+Starting an entry which was already started is rejected, use pause and resume instead.`,
+	Run: func(Cli *cobra.Command, args []string) {
+		runEntryAction("start", args)
+	},
+}
+
+func init() {
+	rootCli.AddCommand(startCli)
+
+	startCli.Flags().StringVar(&workdayOverride, "override", "", "Start the entry outside of the workday and record why (I.e --override \"On call\")")
+}
@@ -4,25 +4,43 @@ Copyright © 2025 @mitchs-dev <github@mitchs.dev>
 package cli
 
 import (
-	"bytes"
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
-	"strconv"
 	"strings"
 
-	"github.com/mitchs-dev/library-go/generator"
 	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/syncManager"
 	log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
 
+// Exit codes of the sync commands, so that scripts can tell why a sync failed
+const (
+	exitSyncFailed         = 1 // Git failed (I.e a conflict or the remote couldn't be reached)
+	exitSyncDisabled       = 2 // settings.git.sync is not enabled
+	exitSyncNotInitialized = 3 // The logs path is not a git repository yet (Run: worklog sync init)
+)
+
 // syncCli represents the sync command
 var syncCli = &cobra.Command{
 	Use:     "sync",
 	Aliases: []string{"sy"},
 	Short:   "sync your worklog to Git",
-	Long:    `This command will sync your worklog to Git.`,
+	Long: `This command will sync your worklog to Git.
+
+If the logs path isn't a Git repository yet, you will be asked if you want to initialize it (See: worklog sync init).
+
+Your local changes are committed and rebased onto the remote before they are pushed. If a step fails, the logs path is rolled back
+to how it was before the sync and you are told what was done. If a sync was interrupted (I.e it was killed), clean it up with --abort.
+Use --dry-run to see what would be pulled, committed and pushed (See also: worklog sync status).
+
+Use --non-interactive when running from cron, systemd timers or scripts. It never prompts and exits with:
+
+  1 - Git failed (I.e the remote couldn't be reached)
+  2 - Git sync is not enabled in the configuration (settings.git.sync)
+  3 - The logs path is not a Git repository yet (Run: worklog sync init)`,
 	Run: func(Cli *cobra.Command, args []string) {
 
 		log.Debug("Running the sync command")
@@ -32,261 +50,245 @@ var syncCli = &cobra.Command{
 			log.Fatal("Failed to get force flag")
 		}
 
-		// First, ensure that Git is enabled in the configuration file
-		if !configuration.GitSync {
-			log.Fatal("Uh oh! Git is not enabled in the configuration file. Please enable Git and configure it and then try again.")
+		syncManager.NonInteractive, err = Cli.Flags().GetBool("non-interactive")
+		if err != nil {
+			log.Fatal("Failed to get non-interactive flag")
 		}
 
-		// Change to the logs path
-		err = os.Chdir(configuration.LogsPath)
+		abortFlag, err := Cli.Flags().GetBool("abort")
 		if err != nil {
-			log.Fatal("Failed to change to logs path: ", err)
+			log.Fatal("Failed to get abort flag")
 		}
 
-		snapshotTimestamp := generator.StringTimestamp(configuration.ScheduleWorkdayTimezone)
-		commitMessage := "SNAPSHOT: " + snapshotTimestamp
-		var commitHash string
-
-		// Check that the logs path is a Git repository
-		_, err = os.Stat(".git")
-		if err != nil {
-			// Ask the user if they want to initialize a new Git repository
-			log.Warn("It looks like the logs path is not a Git repository. Would you like to initialize it as a new Git repository? (y/n)")
-			var response string
-			_, err := fmt.Scanln(&response)
-			if err != nil {
-				log.Fatal("Failed to read response: ", err)
+		if abortFlag {
+			actions, err := syncManager.Abort()
+			if errors.Is(err, syncManager.ErrNothingToAbort) {
+				log.Info("There is no interrupted sync to abort")
+				return
 			}
-
-			if strings.ToLower(response) == "y" || strings.ToLower(response) == "yes" {
-				// Initialize the Git repository
-				log.Debug("Initializing Git repository")
-				cmd := exec.Command("git", "init")
-				err := cmd.Run()
-				if err != nil {
-					log.Fatal("Failed to initialize Git repository: ", err)
-				}
-			} else {
-				log.Fatal("No problem. You won't be able to sync your worklog to Git until you initialize the logs path as a Git repository.")
+			for _, action := range actions {
+				log.Info(action)
 			}
-
-			log.Info("Git repository initialized")
-			log.Warn("There is one extra step to complete. You will need to create the remote repository on your Git server (Github, Gitlab, etc) and then we can sync your worklog to Git.")
-			log.Info("Once you have created the remote repository, I will go ahead and add it as the remote origin for you.")
-			usernameAndRepository := strings.Split(configuration.GitUri, ":")[1]
-			usernameAndRepository = strings.TrimSuffix(usernameAndRepository, ".git")
-			log.Warn("Make sure that you use the same exact account and repository (" + usernameAndRepository + ") on your Git server.")
-			log.Info("Please press any key to continue once you have completed this step.")
-			fmt.Scanln()
-
-			// Add the remote origin
-			log.Debug("Adding remote origin")
-			cmd := exec.Command("git", "remote", "add", "origin", configuration.GitUri)
-			err = cmd.Run()
 			if err != nil {
-				log.Fatal("Failed to add remote origin: ", err)
+				exitSync(err, "Failed to abort the sync")
 			}
+			log.Info("The interrupted sync was aborted")
+			return
+		}
 
-			log.Info("Remote origin added")
+		dryRunFlag, err := Cli.Flags().GetBool("dry-run")
+		if err != nil {
+			log.Fatal("Failed to get dry-run flag")
+		}
 
-			log.Debug("Add the branch: ", configuration.GitBranch)
-			cmd = exec.Command("git", "checkout", "-b", configuration.GitBranch)
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to add branch: ", err)
-			}
+		if !configuration.GitSync {
+			exitSync(syncManager.ErrSyncDisabled, "Uh oh! Git is not enabled in the configuration file. Please enable Git and configure it and then try again.")
+		}
 
-			log.Debug("Running git add .")
-			cmd = exec.Command("git", "add", ".")
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to add files: ", err)
-			}
+		if dryRunFlag {
+			runSyncStatus("text", true)
+			return
+		}
 
-			log.Debug("Running git commit -m \"Initial commit\"")
-			cmd = exec.Command("git", "commit", "-m", commitMessage)
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to commit files: ", err)
+		if !syncManager.IsRepository() {
+			if syncManager.NonInteractive {
+				exitSync(syncManager.ErrNotRepository, "The logs path ("+configuration.LogsPath+") is not a Git repository. Run: worklog sync init")
 			}
-
-			// Get the commit hash
-			log.Debug("Getting the commit hash")
-			cmd = exec.Command("git", "rev-parse", "HEAD")
-			output, err := cmd.Output()
+			initialize, err := confirm("It looks like the logs path is not a Git repository. Would you like to initialize it and push it to " + configuration.GitUri + "?")
 			if err != nil {
-				log.Fatal("Failed to get commit hash: ", err)
+				exitSync(syncManager.ErrNotRepository, "The logs path ("+configuration.LogsPath+") is not a Git repository. Run: worklog sync init")
+			}
+			if !initialize {
+				exitSync(syncManager.ErrNotRepository, "No problem. You won't be able to sync your worklog to Git until you run: worklog sync init")
 			}
+			runSyncInit(syncManager.InitOptions{})
+			return
+		}
 
-			commitHash = strings.TrimSpace(string(output))
+		result, err := syncManager.Sync(forceFlag)
+		for _, action := range result.RolledBack {
+			log.Info("Rollback: " + action)
+		}
+		if err != nil {
+			exitSync(err, "Failed to sync")
+		}
 
-			log.Debug("Running git push origin " + configuration.GitBranch)
-			cmd = exec.Command("git", "push", "origin", configuration.GitBranch)
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to push changes: ", err)
-			}
-		} else {
+		if result.UpToDate {
+			fmt.Println("You're up to date!")
+			return
+		}
 
-			log.Debug("Fetching the remote origin")
-			cmd := exec.Command("git", "fetch", "origin")
-			err := cmd.Run()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to fetch remote origin: %v\n", err)
-				log.Fatal("Failed to fetch remote origin: ", err)
-			}
+		for _, renumbered := range result.Renumbered {
+			log.Warn("Entry " + renumbered.OldID + " (" + renumbered.Week + ") is now " + renumbered.NewID + ", because another machine added an entry with the same ID")
+		}
 
-			// Check if we're up to date by comparing local and remote HEADs
-			cmd = exec.Command("git", "rev-parse", "HEAD")
-			localHead, err := cmd.Output()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to get local HEAD: %v\n", err)
-				log.Fatal("Failed to get local HEAD: ", err)
-			}
+		log.Info("Worklog synced to Git (Commit: " + result.Commit + ")")
+	},
+}
 
-			cmd = exec.Command("git", "rev-parse", "origin/"+configuration.GitBranch)
-			remoteHead, err := cmd.Output()
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to get remote HEAD: %v\n", err)
-				log.Fatal("Failed to get remote HEAD: ", err)
-			}
+// syncInitCli represents the sync init command
+var syncInitCli = &cobra.Command{
+	Use:   "init",
+	Short: "Initialize your logs path as a Git repository",
+	Long: `This command will initialize your logs path as a Git repository, commit your worklog and push it to the remote.
 
-			// If the local and remote HEADs are equal, we're up to date
-			if bytes.Equal(bytes.TrimSpace(localHead), bytes.TrimSpace(remoteHead)) {
-
-				// Determine if there are any local changes
-				cmd = exec.Command("git", "status", "--porcelain")
-				output, err := cmd.Output()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to get status: %v\n", err)
-					log.Fatal("Failed to get status: ", err)
-				}
-
-				// If there are no local changes, exit
-				if len(output) == 0 {
-					fmt.Println("You're up to date!")
-					os.Exit(0)
-				}
-
-				// Find out how many files have changed
-				changedFiles := strings.Split(string(output), "\n")
-				changedFiles = changedFiles[:len(changedFiles)-1]
-				totalChanges := len(changedFiles)
-
-				// If we have local changes, continue
-				log.Info("No remote changes detected, but have " + fmt.Sprint(totalChanges) + " local changes.")
-
-			} else {
-
-				// Find out how many commits are behind
-				cmd = exec.Command("git", "rev-list", "--count", "HEAD..origin/"+configuration.GitBranch)
-				output, err := cmd.Output()
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to get commit count: %v\n", err)
-					log.Fatal("Failed to get commit count: ", err)
-				}
-
-				// Convert the commits we are behind to a count
-				commitsBehind := strings.TrimSpace(string(output))
-				commitsBehindCount, err := strconv.Atoi(commitsBehind)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Failed to convert commits behind to int: %v\n", err)
-					log.Fatal("Failed to convert commits behind to int: ", err)
-				}
-
-				log.Info("Remote has " + fmt.Sprint(commitsBehindCount) + " commits ahead of local")
-
-				// If we're not up to date, stash any changes and pull the latest changes
-
-				log.Debug("Stashing any changes")
-				cmd = exec.Command("git", "stash")
-				err = cmd.Run()
-				if err != nil {
-					log.Fatal("Failed to stash changes: ", err)
-				}
-
-				log.Debug("Checking for any upstream changes")
-				cmd = exec.Command("git", "pull", "origin", configuration.GitBranch)
-				var stderr bytes.Buffer
-				cmd.Stderr = &stderr
-				err = cmd.Run()
-				if err != nil {
-					log.Fatalf("Failed to pull changes: %s\n%s\n", err, stderr.String())
-				}
-
-				// Pop the stash
-				log.Debug("Popping the stash")
-				cmd = exec.Command("git", "stash", "pop")
-				err = cmd.Run()
-				if err != nil {
-					log.Fatal("Failed to pop the stash: ", err)
-				}
-
-				// Find out how many local changes we have
-				cmd = exec.Command("git", "status", "--porcelain")
-				output, err = cmd.Output()
-				if err != nil {
-					log.Fatal("Failed to get status: ", err)
-				}
-
-				// If there are no local changes, exit
-				if len(output) == 0 {
-					fmt.Println("You're up to date!")
-					os.Exit(0)
-				}
-
-				// Find out how many files have changed
-				changedFiles := strings.Split(string(output), "\n")
-				changedFiles = changedFiles[:len(changedFiles)-1]
-				totalChanges := len(changedFiles)
-
-				// If we have local changes, continue
-				log.Info("Have " + fmt.Sprint(totalChanges) + " local changes.")
+Create the remote repository on your Git server (GitHub, GitLab, etc) before running this command. It can be empty.
+The remote and branch default to settings.git.uri and settings.git.branch.
+The branch is pushed as the upstream of your logs path, so later syncs use it even when it differs from the configuration.`,
+	Run: func(Cli *cobra.Command, args []string) {
 
-			}
+		log.Debug("Running the sync init command")
 
-			log.Debug("Running git add .")
-			cmd = exec.Command("git", "add", ".")
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to add files: ", err)
-			}
+		yes, err := Cli.Flags().GetBool("yes")
+		if err != nil {
+			log.Fatal("Failed to get yes flag")
+		}
 
-			log.Debug("Running git commit -m \"" + commitMessage + "\"")
-			cmd = exec.Command("git", "commit", "-m", commitMessage)
-			err = cmd.Run()
-			if err != nil {
-				log.Fatal("Failed to commit files: ", err)
-			}
+		var options syncManager.InitOptions
 
-			// Get the commit hash
-			log.Debug("Getting the commit hash")
-			cmd = exec.Command("git", "rev-parse", "HEAD")
-			output, err := cmd.Output()
-			if err != nil {
-				log.Fatal("Failed to get commit hash: ", err)
-			}
+		options.Remote, err = Cli.Flags().GetString("remote")
+		if err != nil {
+			log.Fatal("Failed to get remote flag")
+		}
 
-			commitHash = strings.TrimSpace(string(output))
+		options.Branch, err = Cli.Flags().GetString("branch")
+		if err != nil {
+			log.Fatal("Failed to get branch flag")
+		}
 
-			log.Debug("Running git push origin " + configuration.GitBranch)
-			if forceFlag {
-				cmd = exec.Command("git", "push", "origin", configuration.GitBranch, "--force")
-			} else {
-				cmd = exec.Command("git", "push", "origin", configuration.GitBranch)
+		if !yes {
+			remote := options.Remote
+			if remote == "" {
+				remote = configuration.GitUri
 			}
-			err = cmd.Run()
+			initialize, err := confirm("Initialize " + configuration.LogsPath + " as a Git repository and push it to " + remote + "?")
 			if err != nil {
-				log.Fatal("Failed to push changes: ", err)
+				exitSync(err, "Failed to confirm (Use --yes when running without a terminal)")
 			}
+			if !initialize {
+				log.Info("Nothing was changed")
+				return
+			}
+		}
+
+		// There is no one to answer a prompt once it was confirmed (or --yes was used)
+		syncManager.NonInteractive = yes
+
+		runSyncInit(options)
+	},
+}
+
+// syncStatusCli represents the sync status command
+var syncStatusCli = &cobra.Command{
+	Use:   "status",
+	Short: "Show what is not synced to Git yet",
+	Long: `This command will fetch the remote and show how many commits your worklog is ahead and behind it.
+
+The week files which changed locally (Committed or not) are compared with the remote entry by entry,
+so you can see which entries were added, changed or removed. Nothing is committed or pushed.`,
+	Run: func(Cli *cobra.Command, args []string) {
+
+		log.Debug("Running the sync status command")
+
+		outputFormat, err := Cli.Flags().GetString("output")
+		if err != nil {
+			log.Fatal("Failed to get output flag")
+		}
+
+		syncManager.NonInteractive, err = Cli.Flags().GetBool("non-interactive")
+		if err != nil {
+			log.Fatal("Failed to get non-interactive flag")
 		}
-		log.Info("Worklog synced to Git (Commit: " + commitHash + ")")
 
+		runSyncStatus(outputFormat, false)
 	},
 }
 
+// runSyncStatus fetches the remote and prints the sync status (Or what sync would do with dryRun)
+func runSyncStatus(outputFormat string, dryRun bool) {
+
+	status, err := syncManager.GetStatus(true)
+	if err != nil {
+		exitSync(err, "Failed to get the sync status")
+	}
+
+	stdReturn, err := syncManager.FormatStatus(outputFormat, status, dryRun)
+	if err != nil {
+		log.Fatal("Failed to format the sync status: ", err)
+	}
+
+	fmt.Println(stdReturn)
+}
+
+// runSyncInit initializes the logs path and reports the result
+func runSyncInit(options syncManager.InitOptions) {
+
+	commitHash, err := syncManager.Init(options)
+	if err != nil {
+		exitSync(err, "Failed to initialize the Git repository")
+	}
+
+	log.Info("Worklog initialized and pushed to Git (Commit: " + commitHash + ")")
+
+	if !configuration.GitSync {
+		log.Warn("Git sync is not enabled yet. Set settings.git.sync to true to sync your worklog with: worklog sync")
+	}
+}
+
+// confirm asks a yes/no question on the terminal
+// It fails instead of blocking when there is no terminal (I.e cron)
+func confirm(question string) (bool, error) {
+
+	stdinInfo, err := os.Stdin.Stat()
+	if err != nil || stdinInfo.Mode()&os.ModeCharDevice == 0 {
+		return false, errors.New("no terminal to ask: " + question)
+	}
+
+	log.Warn(question + " (y/n)")
+
+	response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false, errors.New("failed to read response: " + err.Error())
+	}
+
+	response = strings.ToLower(strings.TrimSpace(response))
+	return response == "y" || response == "yes", nil
+}
+
+// exitSync logs the error and exits with the exit code of the error
+func exitSync(err error, message string) {
+
+	exitCode := exitSyncFailed
+	switch {
+	case errors.Is(err, syncManager.ErrSyncDisabled):
+		exitCode = exitSyncDisabled
+	case errors.Is(err, syncManager.ErrNotRepository):
+		exitCode = exitSyncNotInitialized
+	}
+
+	if errors.Is(err, syncManager.ErrSyncDisabled) || errors.Is(err, syncManager.ErrNotRepository) {
+		log.Error(message)
+	} else {
+		log.Error(message, ": ", err)
+	}
+
+	os.Exit(exitCode)
+}
+
 func init() {
 	rootCli.AddCommand(syncCli)
+	syncCli.AddCommand(syncInitCli)
+	syncCli.AddCommand(syncStatusCli)
 
 	syncCli.Flags().BoolP("force", "", false, "Force your worklog to sync to Git")
+	syncCli.Flags().BoolP("non-interactive", "", false, "Never prompt, exit with an error code instead (I.e for cron)")
+	syncCli.Flags().BoolP("abort", "", false, "Roll back a sync which was interrupted")
+	syncCli.Flags().BoolP("dry-run", "", false, "Only show what would be pulled, committed and pushed")
+
+	syncStatusCli.Flags().StringP("output", "o", "text", "The output format ("+strings.Join(syncManager.StatusFormats, ", ")+")")
+	syncStatusCli.Flags().BoolP("non-interactive", "", false, "Never prompt, exit with an error code instead (I.e for cron)")
+
+	syncInitCli.Flags().BoolP("yes", "y", false, "Don't ask for confirmation")
+	syncInitCli.Flags().StringP("remote", "", "", "The Git URI of the remote (Defaults to settings.git.uri)")
+	syncInitCli.Flags().StringP("branch", "", "", "The branch to push to (Defaults to settings.git.branch)")
 }
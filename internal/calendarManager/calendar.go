@@ -0,0 +1,101 @@
+package calendarManager
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file holds the calendar engine which maps days to the week files they are stored in
+//
+// Week files are named by the ISO year and week of the day (I.e 2026/12), so the ISO year of a day
+// is not always its calendar year (I.e 2024-12-30 is stored in 2025/01 and 2027-01-01 is stored in 2026/53)
+
+// startOfDay returns midnight of the date in the date's location
+func startOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
+}
+
+// weekPath returns the week file of the date in the format "YYYY/WW"
+func weekPath(date time.Time) string {
+	year, week := date.ISOWeek()
+	return fmt.Sprintf("%d/%02d", year, week)
+}
+
+// WeekDayDate returns the date of a month/day (MMDD) stored in a week file (YYYY/WW)
+// The calendar year is not always the ISO year of the week (I.e 0101 in 2026/53 is 2027-01-01)
+func WeekDayDate(week, monthDay string) (time.Time, error) {
+
+	var isoYear, isoWeek int
+	if _, err := fmt.Sscanf(week, "%d/%d", &isoYear, &isoWeek); err != nil {
+		return time.Time{}, errors.New("invalid week (" + week + "), expected format YYYY/WW")
+	}
+
+	for _, year := range []int{isoYear, isoYear + 1, isoYear - 1} {
+		date, err := time.ParseInLocation("20060102", fmt.Sprintf("%d%s", year, monthDay), Location())
+		if err != nil {
+			continue
+		}
+		if dateYear, dateWeek := date.ISOWeek(); dateYear == isoYear && dateWeek == isoWeek {
+			return date, nil
+		}
+	}
+
+	return time.Time{}, errors.New("month/day (" + monthDay + ") is not in week " + week)
+}
+
+// namedPeriodRange returns the first and last day of a named period (I.e today, cweek, month) relative to now
+func namedPeriodRange(period string, now time.Time) (time.Time, time.Time) {
+
+	today := startOfDay(now)
+
+	switch period {
+	case "cweek":
+		// The current week runs from the most recent start day to the following end day
+		startDay := parseWeekday(configuration.ScheduleDaysStart)
+		endDay := parseWeekday(configuration.ScheduleDaysEnd)
+		startDate := today.AddDate(0, 0, -((int(today.Weekday()) - int(startDay) + 7) % 7))
+		return startDate, startDate.AddDate(0, 0, (int(endDay)-int(startDay)+7)%7)
+	case "yesterday":
+		return today.AddDate(0, 0, periodMap[period]), today.AddDate(0, 0, periodMap[period])
+	default:
+		return today.AddDate(0, 0, periodMap[period]), today
+	}
+}
+
+// rangeFetch builds the weeks and year tree for every day between the start and end date (inclusive)
+// Each day is placed under the ISO year and week it is stored in, so weeks spanning the new year and week 53 are handled
+func rangeFetch(startDate, endDate time.Time) ([]string, YearTree, string, string, error) {
+
+	startDate = startOfDay(startDate)
+	endDate = startOfDay(endDate)
+
+	weeks := []string{}
+	yearTree := YearTree{
+		Years: make(map[int]WeekTree),
+	}
+
+	for currentDate := startDate; !currentDate.After(endDate); currentDate = currentDate.AddDate(0, 0, 1) {
+		year, week := currentDate.ISOWeek()
+
+		if _, ok := yearTree.Years[year]; !ok {
+			log.Debug("Adding year to year tree: ", year)
+			yearTree.Years[year] = WeekTree{
+				Weeks: make(map[int]MonthDayTree),
+			}
+		}
+
+		weekPtr, ok := yearTree.Years[year].Weeks[week]
+		if !ok {
+			log.Debug("Adding week to year tree: ", week)
+			weeks = append(weeks, weekPath(currentDate))
+		}
+		weekPtr.MonthDays = append(weekPtr.MonthDays, currentDate.Format("0102"))
+		yearTree.Years[year].Weeks[week] = weekPtr
+	}
+
+	return weeks, yearTree, startDate.Format("0102"), endDate.Format("0102"), nil
+}
@@ -0,0 +1,10 @@
+package calendarManager
+
+import "errors"
+
+// This file holds the variables associated with the calendar manager
+
+// Errors returned by the calendar manager (Check for them with errors.Is)
+var (
+	ErrInvalidPeriod = errors.New("invalid period")
+)
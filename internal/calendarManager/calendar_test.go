@@ -0,0 +1,318 @@
+package calendarManager
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+)
+
+// date returns midnight of the date in UTC
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// pinClock pins the clock to now in UTC until the test is done
+func pinClock(t *testing.T, now time.Time) {
+	t.Helper()
+	SetClock(func() time.Time { return now }, time.UTC)
+	t.Cleanup(func() { SetClock(nil, nil) })
+}
+
+func TestRangeFetch(t *testing.T) {
+
+	pinClock(t, date(2026, time.October, 17))
+
+	tests := []struct {
+		name          string
+		start, end    time.Time
+		wantWeeks     []string
+		wantMonthDays map[string][]string // Week (YYYY/WW) -> month/days
+	}{
+		{
+			name:      "week 1 of 2025 starts in December 2024",
+			start:     date(2024, time.December, 30),
+			end:       date(2025, time.January, 5),
+			wantWeeks: []string{"2025/01"},
+			wantMonthDays: map[string][]string{
+				"2025/01": {"1230", "1231", "0101", "0102", "0103", "0104", "0105"},
+			},
+		},
+		{
+			name:      "week 1 of 2026 starts in December 2025",
+			start:     date(2025, time.December, 29),
+			end:       date(2026, time.January, 4),
+			wantWeeks: []string{"2026/01"},
+			wantMonthDays: map[string][]string{
+				"2026/01": {"1229", "1230", "1231", "0101", "0102", "0103", "0104"},
+			},
+		},
+		{
+			name:      "2026 has 53 weeks",
+			start:     date(2026, time.December, 28),
+			end:       date(2027, time.January, 4),
+			wantWeeks: []string{"2026/53", "2027/01"},
+			wantMonthDays: map[string][]string{
+				"2026/53": {"1228", "1229", "1230", "1231", "0101", "0102", "0103"},
+				"2027/01": {"0104"},
+			},
+		},
+		{
+			name:      "2032 has 53 weeks",
+			start:     date(2032, time.December, 26),
+			end:       date(2033, time.January, 3),
+			wantWeeks: []string{"2032/52", "2032/53", "2033/01"},
+			wantMonthDays: map[string][]string{
+				"2032/52": {"1226"},
+				"2032/53": {"1227", "1228", "1229", "1230", "1231", "0101", "0102"},
+				"2033/01": {"0103"},
+			},
+		},
+		{
+			name:      "new year in the middle of week 1",
+			start:     date(2029, time.December, 28),
+			end:       date(2030, time.January, 3),
+			wantWeeks: []string{"2029/52", "2030/01"},
+			wantMonthDays: map[string][]string{
+				"2029/52": {"1228", "1229", "1230"},
+				"2030/01": {"1231", "0101", "0102", "0103"},
+			},
+		},
+		{
+			name:      "new year at the start of week 1",
+			start:     date(2030, time.December, 29),
+			end:       date(2031, time.January, 1),
+			wantWeeks: []string{"2030/52", "2031/01"},
+			wantMonthDays: map[string][]string{
+				"2030/52": {"1229"},
+				"2031/01": {"1230", "1231", "0101"},
+			},
+		},
+		{
+			name:          "start after end",
+			start:         date(2027, time.January, 2),
+			end:           date(2027, time.January, 1),
+			wantWeeks:     []string{},
+			wantMonthDays: map[string][]string{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			weeks, yearTree, _, _, err := rangeFetch(test.start, test.end)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(weeks, test.wantWeeks) {
+				t.Errorf("weeks = %v, want %v", weeks, test.wantWeeks)
+			}
+
+			monthDays := make(map[string][]string)
+			for year, weekTree := range yearTree.Years {
+				for week, monthDayTree := range weekTree.Weeks {
+					monthDays[weekPath(isoWeekStart(year, week))] = monthDayTree.MonthDays
+				}
+			}
+			if !reflect.DeepEqual(monthDays, test.wantMonthDays) {
+				t.Errorf("month/days = %v, want %v", monthDays, test.wantMonthDays)
+			}
+		})
+	}
+}
+
+// isoWeekStart returns the Monday of an ISO week
+func isoWeekStart(year, week int) time.Time {
+	// January 4th is always in week 1
+	jan4 := date(year, time.January, 4)
+	return jan4.AddDate(0, 0, -((int(jan4.Weekday())+6)%7)+(week-1)*7)
+}
+
+// TestRangeFetchDecade walks every day of the next decade and checks that each day is in exactly one week,
+// that the week can be turned back into the day, and that only 2026 and 2032 have 53 weeks
+// (2035-12-31 is in 2036/01, so 2036 is in the tree as well)
+func TestRangeFetchDecade(t *testing.T) {
+
+	pinClock(t, date(2026, time.October, 17))
+
+	weeks, yearTree, _, _, err := rangeFetch(date(2026, time.January, 1), date(2035, time.December, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantWeeksInYear := map[int]int{2026: 53, 2027: 52, 2028: 52, 2029: 52, 2030: 52, 2031: 52, 2032: 53, 2033: 52, 2034: 52, 2035: 52}
+	for year, want := range wantWeeksInYear {
+		if got := len(yearTree.Years[year].Weeks); got != want {
+			t.Errorf("%d has %d weeks, want %d", year, got, want)
+		}
+	}
+
+	for i := 1; i < len(weeks); i++ {
+		if weeks[i] <= weeks[i-1] {
+			t.Errorf("week %s comes after %s", weeks[i], weeks[i-1])
+		}
+	}
+
+	days, weekCount := 0, 0
+	for year, weekTree := range yearTree.Years {
+		for weekNumber, monthDayTree := range weekTree.Weeks {
+			weekCount++
+			week := weekPath(isoWeekStart(year, weekNumber))
+			for _, monthDay := range monthDayTree.MonthDays {
+				days++
+				got, err := WeekDayDate(week, monthDay)
+				if err != nil {
+					t.Errorf("WeekDayDate(%s, %s): %v", week, monthDay, err)
+					continue
+				}
+				if weekPath(got) != week || got.Format("0102") != monthDay {
+					t.Errorf("WeekDayDate(%s, %s) = %s, which is not in the week", week, monthDay, got.Format("2006-01-02"))
+				}
+			}
+		}
+	}
+
+	if weekCount != len(weeks) {
+		t.Errorf("the year tree has %d weeks, but %d weeks were returned", weekCount, len(weeks))
+	}
+
+	wantDays := int(date(2036, time.January, 1).Sub(date(2026, time.January, 1)).Hours() / 24)
+	if days != wantDays {
+		t.Errorf("got %d days, want %d", days, wantDays)
+	}
+}
+
+func TestWeekDayDate(t *testing.T) {
+
+	pinClock(t, date(2026, time.October, 17))
+
+	tests := []struct {
+		week, monthDay string
+		want           time.Time
+		wantErr        bool
+	}{
+		{week: "2026/42", monthDay: "1017", want: date(2026, time.October, 17)},
+		{week: "2025/01", monthDay: "1230", want: date(2024, time.December, 30)},
+		{week: "2025/01", monthDay: "0105", want: date(2025, time.January, 5)},
+		{week: "2026/01", monthDay: "1229", want: date(2025, time.December, 29)},
+		{week: "2026/53", monthDay: "1228", want: date(2026, time.December, 28)},
+		{week: "2026/53", monthDay: "0101", want: date(2027, time.January, 1)},
+		{week: "2026/53", monthDay: "0103", want: date(2027, time.January, 3)},
+		{week: "2032/53", monthDay: "0102", want: date(2033, time.January, 2)},
+		{week: "2030/01", monthDay: "1231", want: date(2029, time.December, 31)},
+		{week: "2028/09", monthDay: "0229", want: date(2028, time.February, 29)},
+		{week: "2026/53", monthDay: "0104", wantErr: true},
+		{week: "2027/09", monthDay: "0229", wantErr: true},
+		{week: "2026-42", monthDay: "1017", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.week+"/"+test.monthDay, func(t *testing.T) {
+			got, err := WeekDayDate(test.week, test.monthDay)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("got %s, want an error", got.Format("2006-01-02"))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("got %s, want %s", got.Format("2006-01-02"), test.want.Format("2006-01-02"))
+			}
+		})
+	}
+}
+
+func TestMonthDayWeek(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		monthDay string
+		want     string
+		wantErr  bool
+	}{
+		{name: "today", now: date(2026, time.October, 17), monthDay: "1017", want: "2026/42"},
+		{name: "later this year is last year, which is in week 1", now: date(2026, time.October, 17), monthDay: "1231", want: "2026/01"},
+		{name: "new year's day in week 53", now: date(2027, time.January, 2), monthDay: "0101", want: "2026/53"},
+		{name: "last year in week 53", now: date(2027, time.January, 2), monthDay: "1231", want: "2026/53"},
+		{name: "december in week 1", now: date(2025, time.January, 1), monthDay: "1230", want: "2025/01"},
+		{name: "december in week 1 of 2026", now: date(2026, time.January, 2), monthDay: "1229", want: "2026/01"},
+		{name: "week 53 of 2032", now: date(2033, time.January, 2), monthDay: "1227", want: "2032/53"},
+		{name: "new year crossing", now: date(2030, time.January, 3), monthDay: "1228", want: "2029/52"},
+		{name: "leap day", now: date(2026, time.October, 17), monthDay: "0229", want: "2024/09"},
+		{name: "leap day this year", now: date(2032, time.March, 1), monthDay: "0229", want: "2032/09"},
+		{name: "invalid month", now: date(2026, time.October, 17), monthDay: "1301", wantErr: true},
+		{name: "too short", now: date(2026, time.October, 17), monthDay: "101", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pinClock(t, test.now)
+			got, err := MonthDayWeek(test.monthDay)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("got %s, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestOutsideWorkday(t *testing.T) {
+
+	enabled, start, end := configuration.ScheduleWorkdayEnabled, configuration.ScheduleWorkdayStart, configuration.ScheduleWorkdayEnd
+	daysStart, daysEnd := configuration.ScheduleDaysStart, configuration.ScheduleDaysEnd
+	t.Cleanup(func() {
+		configuration.ScheduleWorkdayEnabled, configuration.ScheduleWorkdayStart, configuration.ScheduleWorkdayEnd = enabled, start, end
+		configuration.ScheduleDaysStart, configuration.ScheduleDaysEnd = daysStart, daysEnd
+	})
+	configuration.ScheduleWorkdayEnabled = true
+	configuration.ScheduleDaysStart, configuration.ScheduleDaysEnd = "Monday", "Friday"
+
+	// Friday October 16, 2026
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name        string
+		start, end  string
+		now         time.Time
+		wantOutside bool
+	}{
+		{"during the workday", "09:00", "17:00", at(16, 9, 0), false},
+		{"at the end of the workday", "09:00", "17:00", at(16, 17, 0), true},
+		{"before the workday", "09:00", "17:00", at(16, 8, 59), true},
+		{"on the weekend", "09:00", "17:00", at(17, 10, 0), true},
+		{"overnight before midnight", "22:00", "06:00", at(16, 23, 0), false},
+		// The hours after midnight belong to Friday
+		{"overnight after midnight", "22:00", "06:00", at(17, 5, 59), false},
+		{"overnight during the day", "22:00", "06:00", at(16, 12, 0), true},
+		// ConfigInit rejects a workday without hours, but every time is outside of it
+		{"starts when it ends", "09:00", "09:00", at(16, 9, 0), true},
+	}
+
+	for _, test := range tests {
+		configuration.ScheduleWorkdayStart, configuration.ScheduleWorkdayEnd = test.start, test.end
+		reason, err := OutsideWorkday(test.now)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if outside := reason != ""; outside != test.wantOutside {
+			t.Errorf("%s: outside %t (%q), want %t", test.name, outside, reason, test.wantOutside)
+		}
+	}
+}
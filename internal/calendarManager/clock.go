@@ -0,0 +1,59 @@
+package calendarManager
+
+import (
+	"time"
+
+	"github.com/mitchs-dev/library-go/generator"
+	"github.com/mitchs-dev/worklog/internal/configuration"
+)
+
+// This file holds the clock used for every date calculation
+//
+// All dates are in the configured timezone (settings.schedule.workday.timezone) so that an entry
+// is always stored and listed under the same day, regardless of the timezone of the machine
+
+var (
+	// clockNow returns the current time (Can be pinned with SetClock)
+	clockNow = time.Now
+
+	// clockLocation is the timezone of the clock (Uses the configured timezone when nil)
+	clockLocation *time.Location
+)
+
+// SetClock pins the current time and timezone of the clock (I.e for tests)
+// A nil now or location keeps the default (time.Now and the configured timezone)
+func SetClock(now func() time.Time, location *time.Location) {
+	clockNow = time.Now
+	if now != nil {
+		clockNow = now
+	}
+	clockLocation = location
+}
+
+// Location returns the timezone of the clock
+func Location() *time.Location {
+	if clockLocation != nil {
+		return clockLocation
+	}
+	return generator.GetLocation(configuration.ScheduleWorkdayTimezone)
+}
+
+// Now returns the current time in the timezone of the clock
+func Now() time.Time {
+	return clockNow().In(Location())
+}
+
+// NowEpoch returns the current time as epoch time
+func NowEpoch() int64 {
+	return clockNow().Unix()
+}
+
+// FromEpoch converts epoch time to time in the timezone of the clock
+func FromEpoch(epochTime int64) time.Time {
+	return time.Unix(epochTime, 0).In(Location())
+}
+
+// EndOfDayEpoch returns the epoch time of the midnight after the epoch time, in the timezone of the clock
+func EndOfDayEpoch(epochTime int64) int64 {
+	return startOfDay(FromEpoch(epochTime)).AddDate(0, 0, 1).Unix()
+}
@@ -6,8 +6,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/mitchs-dev/library-go/generator"
-	"github.com/mitchs-dev/worklog/internal/configuration"
 	log "github.com/sirupsen/logrus"
 )
 
@@ -50,120 +48,29 @@ func validPeriod(period string) bool {
 }
 
 // PeriodFetch fetches the period from the calendar and returns all of the weeks in the period in the format "YYYY/WW", the month/days in the period in the format MMDD, and the first and last day of the period in the format of MMDD
+// The period can also be a date range (I.e 2026-03-01..2026-03-31) or a week, month or quarter selector (I.e 2026-W12, 2026-03, 2026-Q1)
 func PeriodFetch(period string) ([]string, YearTree, string, string, error) {
-	if !validPeriod(period) {
-		return nil, YearTree{}, "", "", errors.New("invalid period")
+	startDate, endDate, err := PeriodRange(period)
+	if err != nil {
+		return nil, YearTree{}, "", "", err
 	}
 
-	// Get the current date
-	epochTimestamp := int64(generator.EpochTimestamp(configuration.ScheduleWorkdayTimezone))
-
-	// Convert epoch to time.Time
-	endDate := time.Unix(epochTimestamp, 0)
-
-	// Get the current year and week (end date)
-	endYear, endWeek := endDate.ISOWeek()
-
-	// Subtract the period from the current date
-	startDate := endDate.AddDate(0, 0, periodMap[period])
-
-	// Get the year and week of the start date
-	startYear, startWeek := startDate.ISOWeek()
-
-	// Get the day and month of the start and end dates
-	startDay := startDate.Format("0102")
-
-	// Get the day and month of the end date
-	endDay := endDate.Format("0102")
+	return rangeFetch(startDate, endDate)
+}
 
-	// Create a slice of weeks
-	weeks := []string{}
-	// Build the year tree
-	// Initialize YearTree properly
-	yearTree := YearTree{
-		Years: make(map[int]WeekTree),
+// PeriodRange returns the first and last day of the period (Any period accepted by PeriodFetch)
+func PeriodRange(period string) (time.Time, time.Time, error) {
+	if isRangePeriod(period) {
+		return parseRangePeriod(period)
 	}
-	for year := startYear; year <= endYear; year++ {
-		// Get the start and end week
-		start := 1
-		end := 52
-		if year == startYear {
-			start = startWeek
-		}
-		if year == endYear {
-			end = endWeek
-		}
-
-		// Add the weeks to the slice
-		// Add the year to the year tree
-		log.Debug("Adding year to year tree: ", year)
-		yearTree.Years[year] = WeekTree{
-			Weeks: make(map[int]MonthDayTree),
-		}
-		for week := start; week <= end; week++ {
-			log.Debug("Adding week to year tree: ", week)
-			yearTree.Years[year].Weeks[week] = MonthDayTree{
-				MonthDays: make([]string, 0),
-			}
-
-			// Add the week to the slice
-			weeks = append(weeks, fmt.Sprintf("%d/%02d", year, week))
-
-			// Calculate week start/end dates based on period
-			weekStartDate := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
-			now := time.Now().Local()
-
-			var weekEndDate time.Time
-
-			switch period {
-			case "today":
-				weekStartDate = now
-				weekEndDate = now
-			case "yesterday":
-				weekStartDate = now.AddDate(0, 0, -1)
-				weekEndDate = now.AddDate(0, 0, -1)
-			case "3day":
-				weekStartDate = now.AddDate(0, 0, -2)
-				weekEndDate = now
-			default:
-				// Existing weekly logic
-				startDay := parseWeekday(configuration.ScheduleDaysStart)
-				endDay := parseWeekday(configuration.ScheduleDaysEnd)
-
-				// Find first occurrence of start day
-				for weekStartDate.Weekday() != startDay {
-					weekStartDate = weekStartDate.AddDate(0, 0, 1)
-				}
-
-				// Calculate correct week offset
-				if time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC).Weekday() > startDay {
-					weekStartDate = weekStartDate.AddDate(0, 0, (week-2)*7)
-				} else {
-					weekStartDate = weekStartDate.AddDate(0, 0, (week-1)*7)
-				}
-
-				daysInPeriod := int(endDay-startDay) + 1
-				if daysInPeriod < 0 {
-					daysInPeriod += 7
-				}
-				weekEndDate = weekStartDate.AddDate(0, 0, daysInPeriod-1)
-			}
-
-			// Populate dates
-			currentDate := weekStartDate
-			for currentDate.Before(weekEndDate) || currentDate.Equal(weekEndDate) {
-				monthDay := currentDate.Format("0102")
-				weekPtr := yearTree.Years[year].Weeks[week]
-				weekPtr.MonthDays = append(weekPtr.MonthDays, monthDay)
-				yearTree.Years[year].Weeks[week] = weekPtr
-				currentDate = currentDate.AddDate(0, 0, 1)
-			}
-			// ...existing code...
 
-		}
+	if !validPeriod(period) {
+		return time.Time{}, time.Time{}, fmt.Errorf("%w (%s)", ErrInvalidPeriod, period)
 	}
 
-	return weeks, yearTree, startDay, endDay, nil
+	startDate, endDate := namedPeriodRange(strings.ToLower(period), Now())
+
+	return startDate, endDate, nil
 }
 
 // parseWeekday parses the weekday string to time.Weekday
@@ -187,3 +94,31 @@ func parseWeekday(day string) time.Weekday {
 		return time.Monday // Default to Monday if invalid
 	}
 }
+
+// MonthDayWeek finds the most recent date (today or earlier) matching the month/day in the format MMDD and returns its week in the format "YYYY/WW"
+func MonthDayWeek(monthDay string) (string, error) {
+	if len(monthDay) != 4 {
+		return "", errors.New("invalid month/day: " + monthDay)
+	}
+
+	// Get the current date
+	now := Now()
+
+	// Entries can't be in the future, so walk back from the current year until the month/day exists and has already happened (I.e 0229 needs a leap year)
+	var date time.Time
+	for year := now.Year(); year >= now.Year()-4; year-- {
+		parsedDate, err := time.ParseInLocation("20060102", fmt.Sprintf("%d%s", year, monthDay), now.Location())
+		if err != nil || parsedDate.After(now) {
+			continue
+		}
+		date = parsedDate
+		break
+	}
+	if date.IsZero() {
+		return "", errors.New("invalid month/day: " + monthDay)
+	}
+
+	log.Debugf("Month/day %s resolved to week %s", monthDay, weekPath(date))
+
+	return weekPath(date), nil
+}
@@ -0,0 +1,100 @@
+package calendarManager
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// This file is used to fetch arbitrary date ranges from the calendar (I.e 2026-03-01..2026-03-31, 2026-W12, 2026-03, 2026-Q1)
+
+const (
+	// dateLayout is the layout of the dates used in a date range
+	dateLayout = "2006-01-02"
+	// rangeSeparator separates the start and end date of a date range
+	rangeSeparator = ".."
+)
+
+var (
+	// weekSelector matches an ISO week (I.e 2026-W12)
+	weekSelector = regexp.MustCompile(`^(\d{4})-[Ww](\d{1,2})$`)
+	// monthSelector matches a month (I.e 2026-03)
+	monthSelector = regexp.MustCompile(`^(\d{4})-(\d{2})$`)
+	// quarterSelector matches a quarter (I.e 2026-Q1)
+	quarterSelector = regexp.MustCompile(`^(\d{4})-[Qq]([1-4])$`)
+)
+
+// DateRangePeriod builds a period from a start and end date in the format YYYY-MM-DD (I.e 2026-03-01..2026-03-31)
+// If the end date is empty, the range ends today
+func DateRangePeriod(from, to string) string {
+	return from + rangeSeparator + to
+}
+
+// isRangePeriod checks if the period is a date range or a week/month/quarter selector instead of a named period
+func isRangePeriod(period string) bool {
+	return strings.Contains(period, rangeSeparator) ||
+		weekSelector.MatchString(period) ||
+		monthSelector.MatchString(period) ||
+		quarterSelector.MatchString(period)
+}
+
+// parseRangePeriod parses a date range or a week/month/quarter selector and returns the first and last day of the range
+func parseRangePeriod(period string) (time.Time, time.Time, error) {
+
+	location := Location()
+
+	switch {
+	case strings.Contains(period, rangeSeparator):
+		dates := strings.SplitN(period, rangeSeparator, 2)
+		startDate, err := time.ParseInLocation(dateLayout, dates[0], location)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid start date (%s), expected format YYYY-MM-DD", ErrInvalidPeriod, dates[0])
+		}
+		var endDate time.Time
+		if dates[1] == "" {
+			endDate = startOfDay(Now())
+		} else {
+			endDate, err = time.ParseInLocation(dateLayout, dates[1], location)
+			if err != nil {
+				return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid end date (%s), expected format YYYY-MM-DD", ErrInvalidPeriod, dates[1])
+			}
+		}
+		if endDate.Before(startDate) {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: end date (%s) is before start date (%s)", ErrInvalidPeriod, endDate.Format(dateLayout), startDate.Format(dateLayout))
+		}
+		return startDate, endDate, nil
+
+	case weekSelector.MatchString(period):
+		matches := weekSelector.FindStringSubmatch(period)
+		year, _ := strconv.Atoi(matches[1])
+		week, _ := strconv.Atoi(matches[2])
+		// January 4th is always in the first ISO week of the year
+		startDate := time.Date(year, 1, 4, 0, 0, 0, 0, location)
+		startDate = startDate.AddDate(0, 0, -((int(startDate.Weekday())+6)%7)+(week-1)*7)
+		if isoYear, isoWeek := startDate.ISOWeek(); week < 1 || isoYear != year || isoWeek != week {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid week (%s), %d does not have week %d", ErrInvalidPeriod, period, year, week)
+		}
+		return startDate, startDate.AddDate(0, 0, 6), nil
+
+	case monthSelector.MatchString(period):
+		matches := monthSelector.FindStringSubmatch(period)
+		year, _ := strconv.Atoi(matches[1])
+		month, _ := strconv.Atoi(matches[2])
+		if month < 1 || month > 12 {
+			return time.Time{}, time.Time{}, fmt.Errorf("%w: invalid month (%s), expected format YYYY-MM", ErrInvalidPeriod, period)
+		}
+		startDate := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, location)
+		return startDate, startDate.AddDate(0, 1, -1), nil
+
+	case quarterSelector.MatchString(period):
+		matches := quarterSelector.FindStringSubmatch(period)
+		year, _ := strconv.Atoi(matches[1])
+		quarter, _ := strconv.Atoi(matches[2])
+		startDate := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, location)
+		return startDate, startDate.AddDate(0, 3, -1), nil
+	}
+
+	return time.Time{}, time.Time{}, fmt.Errorf("%w (%s)", ErrInvalidPeriod, period)
+}
@@ -0,0 +1,71 @@
+package calendarManager
+
+import (
+	"errors"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+)
+
+// This file is used to check the time against the configured workday (settings.schedule)
+
+// parseClockTime parses a time of day in the 24-hour format HH:MM and returns the minutes since midnight
+func parseClockTime(clockTime string) (int, error) {
+	parsedTime, err := time.Parse("15:04", clockTime)
+	if err != nil {
+		return 0, errors.New("invalid time (" + clockTime + "), expected 24-hour format HH:MM")
+	}
+	return parsedTime.Hour()*60 + parsedTime.Minute(), nil
+}
+
+// IsWorkDay checks if the weekday is between the start and end day of the work week (inclusive)
+// The work week can wrap around the weekend (I.e Saturday to Wednesday)
+func IsWorkDay(day time.Weekday) bool {
+	startDay := parseWeekday(configuration.ScheduleDaysStart)
+	endDay := parseWeekday(configuration.ScheduleDaysEnd)
+	return (int(day)-int(startDay)+7)%7 <= (int(endDay)-int(startDay)+7)%7
+}
+
+// OutsideWorkday checks the time against the configured work week and workday hours
+// It returns an empty string if the time is within the workday, otherwise the reason it isn't
+// An overnight workday (I.e 22:00 to 06:00) belongs to the day it starts on
+// A workday which starts when it ends has no hours, so every time is outside of it (ConfigInit rejects it)
+func OutsideWorkday(now time.Time) (string, error) {
+
+	if !configuration.ScheduleWorkdayEnabled {
+		return "", nil
+	}
+
+	start, err := parseClockTime(configuration.ScheduleWorkdayStart)
+	if err != nil {
+		return "", errors.New("invalid workday start: " + err.Error())
+	}
+	end, err := parseClockTime(configuration.ScheduleWorkdayEnd)
+	if err != nil {
+		return "", errors.New("invalid workday end: " + err.Error())
+	}
+
+	minutes := now.Hour()*60 + now.Minute()
+	workDay := now.Weekday()
+
+	var withinHours bool
+	if start <= end {
+		withinHours = minutes >= start && minutes < end
+	} else {
+		// Overnight workday, the hours after midnight belong to the previous day
+		withinHours = minutes >= start || minutes < end
+		if minutes < end {
+			workDay = now.AddDate(0, 0, -1).Weekday()
+		}
+	}
+
+	if !withinHours {
+		return "it is outside of the workday hours (" + configuration.ScheduleWorkdayStart + " to " + configuration.ScheduleWorkdayEnd + ")", nil
+	}
+
+	if !IsWorkDay(workDay) {
+		return workDay.String() + " is outside of the work week (" + configuration.ScheduleDaysStart + " to " + configuration.ScheduleDaysEnd + ")", nil
+	}
+
+	return "", nil
+}
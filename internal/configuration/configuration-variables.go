@@ -4,7 +4,18 @@ package configuration
 
 // Logs variables
 var (
-	LogsPath string
+	LogsPath          string
+	LogsRestoreWindow string
+	LogsIndex         bool
+)
+
+// Output variables
+var (
+	OutputTimeFormat     string
+	OutputDurationFormat string
+
+	// OutputDurationFormats are the allowed formats of the time worked on an entry
+	OutputDurationFormats = []string{"short", "clock", "decimal"}
 )
 
 // Git variables
@@ -12,19 +23,16 @@ var (
 	GitSync   bool
 	GitUri    string
 	GitBranch string
+	GitRemote string
 )
 
 // Schedule variables
 var (
-	ScheduleDaysStart       string
-	ScheduleDaysEnd         string
-	ScheduleWorkdayEnabled  bool
-	ScheduleWorkdayStart    string
-	ScheduleWorkdayEnd      string
-	ScheduleWorkdayTimezone string
-)
-
-// Misc variables
-var (
-	AllowedOutputFormats = []string{"json", "yaml", "text"}
+	ScheduleDaysStart          string
+	ScheduleDaysEnd            string
+	ScheduleWorkdayEnabled     bool
+	ScheduleWorkdayEnforcement string
+	ScheduleWorkdayStart       string
+	ScheduleWorkdayEnd         string
+	ScheduleWorkdayTimezone    string
 )
@@ -3,13 +3,15 @@ package configuration
 
 import (
 	"embed"
+	"errors"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	mConfiguration "github.com/mitchs-dev/library-go/configuration"
-	"github.com/mitchs-dev/library-go/loggingFormatter"
 	"github.com/mitchs-dev/library-go/processor"
 	"gopkg.in/yaml.v2"
 )
@@ -33,25 +35,12 @@ var (
 
 	// DefaultConfigurationPath is the path to the default configuration file
 	DefaultConfigurationPath = WorkLogHomeDir + "/config"
-
-	// EnableDebugMode is a flag to enable debug mode
-	EnableDebugMode bool
 )
 
 // Since we need to set the variables before the init function is called
 // We will create a function to initialize the configuration
 // Instead of using the init function
-func ConfigInit() {
-
-	// Set the logging output, format, level
-	log.SetOutput(os.Stdout)
-	log.SetFormatter(&loggingFormatter.Formatter{})
-	if EnableDebugMode {
-		log.SetLevel(log.DebugLevel)
-		log.Debug("Debug mode enabled")
-	} else {
-		log.SetLevel(log.InfoLevel)
-	}
+func ConfigInit() error {
 
 	// Since we will need to unmarshal the default config either way
 	// We will go ahead and load it here
@@ -59,7 +48,7 @@ func ConfigInit() {
 	// Load the default configuration
 	defaultConfigurationData, err := defaultConfigEmbed.ReadFile(defaultConfigEmbedPath)
 	if err != nil {
-		log.Fatal("Error loading default configuration: ", err)
+		return errors.New("error loading default configuration: " + err.Error())
 	}
 
 	// Check if the configuration path is provided before
@@ -80,14 +69,14 @@ func ConfigInit() {
 			log.Info("Creating configuration file at: ", ConfigurationPath)
 
 			if !processor.CreateDirectory(WorkLogHomeDir) {
-				log.Fatal("Error creating worklog home directory")
+				return errors.New("error creating worklog home directory (" + WorkLogHomeDir + ")")
 			}
 
 			log.Debug("Creating worklog home directory")
 
 			// Create the configuration file
 			if !processor.CreateFileAsByte(ConfigurationPath, defaultConfigurationData) {
-				log.Fatal("Error creating configuration file")
+				return errors.New("error creating configuration file (" + ConfigurationPath + ")")
 			}
 
 			log.Debug("Unmarshalling default configuration")
@@ -95,7 +84,7 @@ func ConfigInit() {
 			// Unmarshal the default configuration
 			err = yaml.Unmarshal(defaultConfigurationData, &configurationContext)
 			if err != nil {
-				log.Fatal("Error unmarshalling default configuration: ", err)
+				return errors.New("error unmarshalling default configuration: " + err.Error())
 			}
 
 			log.Info("Configuration file created at: ", ConfigurationPath)
@@ -105,20 +94,9 @@ func ConfigInit() {
 
 			log.Debug("Configuration file exists")
 
-			log.Debug("Loading configuration")
-
-			// Load the configuration
-			configurationData, err := os.ReadFile(ConfigurationPath)
-			if err != nil {
-				log.Fatal("Error loading configuration: ", err)
-			}
-
-			log.Debug("Unmarshalling configuration")
-
-			// Unmarshal the configuration
-			err = yaml.Unmarshal(configurationData, &configurationContext)
-			if err != nil {
-				log.Fatal("Error unmarshalling configuration: ", err)
+			// Merge the defaults, so that settings added after the file was created have their default value
+			if err := loadConfiguration(defaultConfigurationData); err != nil {
+				return err
 			}
 		}
 
@@ -128,80 +106,80 @@ func ConfigInit() {
 
 		// Check if the configuration file exists
 		if !processor.DirectoryOrFileExists(ConfigurationPath) {
-			log.Fatal("Configuration file does not exist")
+			return errors.New("configuration file (" + ConfigurationPath + ") does not exist")
 		} else {
 			log.Debug("Configuration file exists")
 		}
 
-		// Define the default configuration model
-		defaultConfiguration := map[interface{}]interface{}{}
-
-		// Define the user configuration model
-		userConfiguration := map[interface{}]interface{}{}
-
-		log.Debug("Unmarshalling default configuration")
-
-		// Unmarshal the default configuration
-		err = yaml.Unmarshal(defaultConfigurationData, &defaultConfiguration)
-		if err != nil {
-			log.Fatal("Error unmarshalling default configuration: ", err)
-		}
-
-		log.Debug("Loading user configuration")
-
-		// Load the configuration
-		configurationData, err := os.ReadFile(ConfigurationPath)
-		if err != nil {
-			log.Fatal("Error loading configuration: ", err)
+		if err := loadConfiguration(defaultConfigurationData); err != nil {
+			return err
 		}
+	}
 
-		log.Debug("Unmarshalling user configuration")
+	setConfigVariables()
 
-		// Unmarshal the user configuration
-		err = yaml.Unmarshal(configurationData, &userConfiguration)
-		if err != nil {
-			log.Fatal("Error unmarshalling configuration: ", err)
+	if LogsPath == "" {
+		return errors.New("logs path not provided in configuration")
+	} else {
+		if strings.Contains(LogsPath, "~") || strings.Contains(LogsPath, "$HOME") {
+			LogsPath = strings.Replace(LogsPath, "~", userHomeDir(), -1)
+			LogsPath = strings.Replace(LogsPath, "$HOME", userHomeDir(), -1)
 		}
+		log.Debug("Logs path: ", LogsPath)
+	}
 
-		log.Debug("Merging configurations")
-
-		// Merge the default and user configurations
-		mergedConfiguration := mConfiguration.MergeWithDefault(defaultConfiguration, userConfiguration)
-
-		log.Debug("Marshalling merged configuration")
+	// Make sure the workday enforcement is valid (An empty enforcement only warns)
+	switch ScheduleWorkdayEnforcement {
+	case "", "warn", "refuse":
+		log.Debug("Workday enforcement: ", ScheduleWorkdayEnforcement)
+	default:
+		return errors.New("invalid workday enforcement (" + ScheduleWorkdayEnforcement + ") in configuration, expected warn or refuse")
+	}
 
-		// Marshal the merged configuration
-		mergedConfigurationMarshalled, err := yaml.Marshal(mergedConfiguration)
-		if err != nil {
-			log.Fatal("Error marshalling merged configuration: ", err)
+	// A workday which starts when it ends has no hours, so every time would be outside of it
+	if ScheduleWorkdayEnabled {
+		start, startErr := time.Parse("15:04", ScheduleWorkdayStart)
+		end, endErr := time.Parse("15:04", ScheduleWorkdayEnd)
+		if startErr == nil && endErr == nil && start.Equal(end) {
+			return errors.New("invalid workday in configuration, the start and end (" + ScheduleWorkdayStart + ") are the same (Disable settings.schedule.workday.enabled to log at any time)")
 		}
+	}
 
-		log.Debug("Unmarshalling merged configuration")
+	// Make sure the duration format is valid (An empty format uses short)
+	if OutputDurationFormat != "" && !slices.Contains(OutputDurationFormats, OutputDurationFormat) {
+		return errors.New("invalid duration format (" + OutputDurationFormat + ") in configuration, expected one of: " + strings.Join(OutputDurationFormats, ", "))
+	}
 
-		// Unmarshal the merged configuration
-		err = yaml.Unmarshal(mergedConfigurationMarshalled, &configurationContext)
-		if err != nil {
-			log.Fatal("Error unmarshalling merged configuration: ", err)
+	// Make sure that the Git URI can be used by sync (An empty URI is allowed if the remote was added by hand)
+	if GitUri != "" {
+		if _, err := ParseGitUri(GitUri); err != nil {
+			return errors.New("invalid settings.git.uri in configuration: " + err.Error())
 		}
 	}
 
-	setConfigVariables()
+	if GitRemote == "" {
+		GitRemote = "origin"
+	}
+	if err := validateGitRemote(GitRemote); err != nil {
+		return errors.New("invalid settings.git.remote in configuration: " + err.Error())
+	}
 
-	if LogsPath == "" {
-		log.Fatal("Logs path not provided in configuration")
-	} else {
-		if strings.Contains(LogsPath, "~") || strings.Contains(LogsPath, "$HOME") {
-			LogsPath = strings.Replace(LogsPath, "~", userHomeDir(), -1)
-			LogsPath = strings.Replace(LogsPath, "$HOME", userHomeDir(), -1)
+	// Every date is calculated in this timezone, so make sure that it is valid instead of silently using UTC
+	if ScheduleWorkdayTimezone != "" {
+		if _, err := time.LoadLocation(ScheduleWorkdayTimezone); err != nil {
+			return errors.New("invalid timezone (" + ScheduleWorkdayTimezone + ") in configuration: " + err.Error())
 		}
-		log.Debug("Logs path: ", LogsPath)
 	}
+
+	return nil
 }
 
+// userHomeDir returns the home directory of the user
+// This is called when the package is loaded, so it only warns instead of exiting (Loading the default configuration will fail instead)
 func userHomeDir() string {
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		log.Fatal("Error retrieving user home directory: ", err)
+		log.Warn("Error retrieving user home directory: ", err)
 	}
 	return homeDir
 }
@@ -212,6 +190,17 @@ func setConfigVariables() {
 	log.Debug("Setting Logs variables")
 	log.Debug("Setting LogsPath")
 	LogsPath = configurationContext.Settings.Logs.Path
+	log.Debug("Setting LogsRestoreWindow")
+	LogsRestoreWindow = configurationContext.Settings.Logs.RestoreWindow
+	log.Debug("Setting LogsIndex")
+	LogsIndex = configurationContext.Settings.Logs.Index
+
+	// Set the Output variables
+	log.Debug("Setting Output variables")
+	log.Debug("Setting OutputTimeFormat")
+	OutputTimeFormat = configurationContext.Settings.Output.TimeFormat
+	log.Debug("Setting OutputDurationFormat")
+	OutputDurationFormat = configurationContext.Settings.Output.DurationFormat
 
 	// Set the Git variables
 	log.Debug("Setting Git variables")
@@ -221,6 +210,8 @@ func setConfigVariables() {
 	GitUri = configurationContext.Settings.Git.Uri
 	log.Debug("Setting GitBranch")
 	GitBranch = configurationContext.Settings.Git.Branch
+	log.Debug("Setting GitRemote")
+	GitRemote = configurationContext.Settings.Git.Remote
 
 	// Set the Schedule variables
 	log.Debug("Setting Schedule variables")
@@ -232,6 +223,8 @@ func setConfigVariables() {
 	log.Debug("Setting ScheduleWorkday variables")
 	log.Debug("Setting ScheduleWorkdayEnabled")
 	ScheduleWorkdayEnabled = configurationContext.Settings.Schedule.Workday.Enabled
+	log.Debug("Setting ScheduleWorkdayEnforcement")
+	ScheduleWorkdayEnforcement = configurationContext.Settings.Schedule.Workday.Enforcement
 	log.Debug("Setting ScheduleWorkdayStart")
 	ScheduleWorkdayStart = configurationContext.Settings.Schedule.Workday.Start
 	log.Debug("Setting ScheduleWorkdayEnd")
@@ -241,3 +234,61 @@ func setConfigVariables() {
 
 	log.Debug("Configuration variables set")
 }
+
+// loadConfiguration loads the configuration file merged with the default configuration
+// Settings which are missing from the configuration file (I.e they were added in a later version) keep their default value
+func loadConfiguration(defaultConfigurationData []byte) error {
+
+	// Define the default configuration model
+	defaultConfiguration := map[interface{}]interface{}{}
+
+	// Define the user configuration model
+	userConfiguration := map[interface{}]interface{}{}
+
+	log.Debug("Unmarshalling default configuration")
+
+	// Unmarshal the default configuration
+	err := yaml.Unmarshal(defaultConfigurationData, &defaultConfiguration)
+	if err != nil {
+		return errors.New("error unmarshalling default configuration: " + err.Error())
+	}
+
+	log.Debug("Loading user configuration")
+
+	// Load the configuration
+	configurationData, err := os.ReadFile(ConfigurationPath)
+	if err != nil {
+		return errors.New("error loading configuration: " + err.Error())
+	}
+
+	log.Debug("Unmarshalling user configuration")
+
+	// Unmarshal the user configuration
+	err = yaml.Unmarshal(configurationData, &userConfiguration)
+	if err != nil {
+		return errors.New("error unmarshalling configuration: " + err.Error())
+	}
+
+	log.Debug("Merging configurations")
+
+	// Merge the default and user configurations
+	mergedConfiguration := mConfiguration.MergeWithDefault(defaultConfiguration, userConfiguration)
+
+	log.Debug("Marshalling merged configuration")
+
+	// Marshal the merged configuration
+	mergedConfigurationMarshalled, err := yaml.Marshal(mergedConfiguration)
+	if err != nil {
+		return errors.New("error marshalling merged configuration: " + err.Error())
+	}
+
+	log.Debug("Unmarshalling merged configuration")
+
+	// Unmarshal the merged configuration
+	err = yaml.Unmarshal(mergedConfigurationMarshalled, &configurationContext)
+	if err != nil {
+		return errors.New("error unmarshalling merged configuration: " + err.Error())
+	}
+
+	return nil
+}
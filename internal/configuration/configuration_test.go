@@ -0,0 +1,88 @@
+package configuration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestConfigInitMergesDefaults checks that settings which are missing from the configuration file keep their default value,
+// whether the configuration file is the default one or is given with -c
+func TestConfigInitMergesDefaults(t *testing.T) {
+
+	dir := t.TempDir()
+	configurationPath := filepath.Join(dir, "config")
+	configurationData := "settings:\n" +
+		"  logs:\n" +
+		"    path: " + filepath.Join(dir, "logs") + "\n"
+	if err := os.WriteFile(configurationPath, []byte(configurationData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	defaultConfigurationPath := DefaultConfigurationPath
+	t.Cleanup(func() {
+		DefaultConfigurationPath = defaultConfigurationPath
+		ConfigurationPath = ""
+	})
+
+	tests := []struct {
+		name              string
+		configurationPath string // The path given with -c ("" for the default path)
+	}{
+		{"default path", ""},
+		{"given path", configurationPath},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			configurationContext = Configuration{}
+			DefaultConfigurationPath = configurationPath
+			ConfigurationPath = test.configurationPath
+
+			if err := ConfigInit(); err != nil {
+				t.Fatal(err)
+			}
+
+			if LogsPath != filepath.Join(dir, "logs") {
+				t.Errorf("logs path %s, want the one from the configuration file", LogsPath)
+			}
+			if !LogsIndex || LogsRestoreWindow != "1d" || GitBranch != "main" {
+				t.Errorf("index %t, restore window %q and branch %q, want the defaults", LogsIndex, LogsRestoreWindow, GitBranch)
+			}
+		})
+	}
+}
+
+func TestConfigInitRejectsAWorkdayWithoutHours(t *testing.T) {
+
+	dir := t.TempDir()
+	t.Cleanup(func() { ConfigurationPath = "" })
+
+	for _, test := range []struct {
+		enabled bool
+		wantErr bool
+	}{
+		{true, true},
+		{false, false},
+	} {
+		configurationContext = Configuration{}
+		ConfigurationPath = filepath.Join(dir, "config")
+		configurationData := "settings:\n" +
+			"  logs:\n" +
+			"    path: " + filepath.Join(dir, "logs") + "\n" +
+			"  schedule:\n" +
+			"    workday:\n" +
+			"      enabled: " + fmt.Sprint(test.enabled) + "\n" +
+			"      start: \"09:00\"\n" +
+			"      end: \"09:00\"\n"
+		if err := os.WriteFile(ConfigurationPath, []byte(configurationData), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := ConfigInit(); (err != nil) != test.wantErr {
+			t.Errorf("enabled %t: got %v, want an error: %t", test.enabled, err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package configuration
+
+import (
+	"errors"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// This file is used to parse and validate the Git URI (settings.git.uri)
+
+var (
+	// gitUriSchemes are the URL schemes that git can push to
+	gitUriSchemes = []string{"ssh", "git+ssh", "ssh+git", "https", "http", "git", "file"}
+
+	// scpLikePattern matches the scp-like SSH syntax (I.e git@github.com:user/repo.git)
+	scpLikePattern = regexp.MustCompile(`^(?:([^@/:]+)@)?([^@/:]+):(.+)$`)
+
+	// windowsPathPattern matches a Windows path (I.e C:\logs), which would otherwise look like the scp-like syntax
+	windowsPathPattern = regexp.MustCompile(`^[A-Za-z]:[\\/]`)
+
+	// localPathPattern matches what a local path must start with: / or \ (Absolute), ./ or ../ (Relative to the logs path) or a Windows drive
+	// Anything else (I.e github.com/user/repo.git) is more likely a URI with a typo than a relative path
+	localPathPattern = regexp.MustCompile(`^(?:[/\\]|\.\.?[/\\]|[A-Za-z]:[\\/])`)
+
+	// gitRemotePattern is what a Git remote name must look like
+	gitRemotePattern = regexp.MustCompile(`^[A-Za-z0-9][\w.-]*$`)
+)
+
+// GitURI is a parsed Git URI
+type GitURI struct {
+	Scheme string // ssh, https, http, git or file (The scp-like syntax is ssh)
+	User   string // The user (I.e git), if there is one
+	Host   string // The host (Empty for local paths)
+	Path   string // The path of the repository on the host, or the local path
+}
+
+// ParseGitUri parses a Git URI in one of the formats that git accepts:
+//
+//	git@github.com:user/repo.git        - SSH (scp-like)
+//	ssh://git@github.com/user/repo.git  - SSH
+//	https://github.com/user/repo.git    - HTTPS
+//	file:///srv/git/repo.git            - Local
+//	/srv/git/repo.git                   - Local (Or a path relative to the logs path such as ../repo.git)
+func ParseGitUri(uri string) (GitURI, error) {
+
+	uri = strings.TrimSpace(uri)
+	if uri == "" {
+		return GitURI{}, errors.New("git uri is empty")
+	}
+
+	invalid := func(reason string) error {
+		return errors.New("invalid git uri (" + uri + "), " + reason)
+	}
+
+	if strings.Contains(uri, "://") {
+		parsed, err := url.Parse(uri)
+		if err != nil {
+			return GitURI{}, invalid(err.Error())
+		}
+		scheme := strings.ToLower(parsed.Scheme)
+		var supported bool
+		for _, gitUriScheme := range gitUriSchemes {
+			if scheme == gitUriScheme {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return GitURI{}, invalid("unsupported scheme (" + parsed.Scheme + "), expected one of: " + strings.Join(gitUriSchemes, ", "))
+		}
+		if scheme == "git+ssh" || scheme == "ssh+git" {
+			scheme = "ssh"
+		}
+		if scheme != "file" && parsed.Host == "" {
+			return GitURI{}, invalid("it is missing the host")
+		}
+		if strings.Trim(parsed.Path, "/") == "" {
+			return GitURI{}, invalid("it is missing the path of the repository")
+		}
+		return GitURI{
+			Scheme: scheme,
+			User:   parsed.User.Username(),
+			Host:   parsed.Host,
+			Path:   parsed.Path,
+		}, nil
+	}
+
+	if !windowsPathPattern.MatchString(uri) {
+		if matches := scpLikePattern.FindStringSubmatch(uri); matches != nil {
+			if strings.Trim(matches[3], "/") == "" {
+				return GitURI{}, invalid("it is missing the path of the repository")
+			}
+			return GitURI{
+				Scheme: "ssh",
+				User:   matches[1],
+				Host:   matches[2],
+				Path:   matches[3],
+			}, nil
+		}
+	}
+
+	// A user before the first / is an SSH URI with a typo (I.e git@github.com/user/repo.git), not a local path
+	if at := strings.Index(uri, "@"); at != -1 {
+		if slash := strings.IndexAny(uri, `/\`); slash == -1 || at < slash {
+			return GitURI{}, invalid("expected user@host:path (I.e git@github.com:user/repo.git), ssh://, https://, file:// or a local path")
+		}
+	}
+
+	if !localPathPattern.MatchString(uri) {
+		return GitURI{}, invalid("a local path has to be absolute or start with ./ or ../ (Or use user@host:path, ssh://, https:// or file://)")
+	}
+
+	return GitURI{
+		Scheme: "file",
+		Path:   uri,
+	}, nil
+}
+
+// Local checks if the Git URI is a repository on this machine
+func (u GitURI) Local() bool {
+	return u.Scheme == "file"
+}
+
+// Repository returns the name of the repository without .git (I.e user/repo, or repo for local paths)
+func (u GitURI) Repository() string {
+	if u.Local() {
+		return strings.TrimSuffix(filepath.Base(filepath.Clean(u.Path)), ".git")
+	}
+	return strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+}
+
+// validateGitRemote checks that the Git remote name can be used by git (I.e origin)
+func validateGitRemote(remote string) error {
+	if !gitRemotePattern.MatchString(remote) || strings.Contains(remote, "..") || strings.HasSuffix(remote, ".lock") {
+		return errors.New("invalid git remote (" + remote + "), only letters, numbers, ., - and _ are allowed")
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+package configuration
+
+import "testing"
+
+func TestParseGitUri(t *testing.T) {
+
+	tests := []struct {
+		uri     string
+		want    GitURI
+		wantErr bool
+	}{
+		{uri: "git@github.com:user/repo.git", want: GitURI{Scheme: "ssh", User: "git", Host: "github.com", Path: "user/repo.git"}},
+		{uri: "ssh://git@github.com/user/repo.git", want: GitURI{Scheme: "ssh", User: "git", Host: "github.com", Path: "/user/repo.git"}},
+		{uri: "git+ssh://git@github.com/user/repo.git", want: GitURI{Scheme: "ssh", User: "git", Host: "github.com", Path: "/user/repo.git"}},
+		{uri: "https://github.com/user/repo.git", want: GitURI{Scheme: "https", Host: "github.com", Path: "/user/repo.git"}},
+		{uri: "file:///srv/git/repo.git", want: GitURI{Scheme: "file", Path: "/srv/git/repo.git"}},
+		{uri: "/srv/git/repo.git", want: GitURI{Scheme: "file", Path: "/srv/git/repo.git"}},
+		{uri: "/srv/git/user@host/repo.git", want: GitURI{Scheme: "file", Path: "/srv/git/user@host/repo.git"}},
+		{uri: "../repo.git", want: GitURI{Scheme: "file", Path: "../repo.git"}},
+		{uri: "./repo.git", want: GitURI{Scheme: "file", Path: "./repo.git"}},
+		{uri: `C:\git\repo.git`, want: GitURI{Scheme: "file", Path: `C:\git\repo.git`}},
+		{uri: `\\server\git\repo.git`, want: GitURI{Scheme: "file", Path: `\\server\git\repo.git`}},
+		{uri: "", wantErr: true},
+		{uri: "git@github.com/user/repo.git", wantErr: true},
+		{uri: `git@github.com\user\repo.git`, wantErr: true},
+		{uri: "git@github.com", wantErr: true},
+		{uri: "github.com/user/repo.git", wantErr: true},
+		{uri: "repo.git", wantErr: true},
+		{uri: "~/repo.git", wantErr: true},
+		{uri: "git@github.com:", wantErr: true},
+		{uri: "ftp://github.com/user/repo.git", wantErr: true},
+		{uri: "https:///user/repo.git", wantErr: true},
+		{uri: "https://github.com/", wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.uri, func(t *testing.T) {
+			got, err := ParseGitUri(test.uri)
+			if test.wantErr {
+				if err == nil {
+					t.Errorf("got %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
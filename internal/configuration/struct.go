@@ -11,19 +11,27 @@ type Configuration struct {
 				End   string `yaml:"end"`
 			} `yaml:"days"`
 			Workday struct {
-				End      string `yaml:"end,omitempty"`
-				Timezone string `yaml:"timezone,omitempty"`
-				Enabled  bool   `yaml:"enabled"`
-				Start    string `yaml:"start,omitempty"`
+				End         string `yaml:"end,omitempty"`
+				Timezone    string `yaml:"timezone,omitempty"`
+				Enabled     bool   `yaml:"enabled"`
+				Enforcement string `yaml:"enforcement,omitempty"`
+				Start       string `yaml:"start,omitempty"`
 			} `yaml:"workday"`
 		} `yaml:"schedule"`
 		Logs struct {
-			Path string `yaml:"path"`
+			Path          string `yaml:"path"`
+			RestoreWindow string `yaml:"restoreWindow,omitempty"`
+			Index         bool   `yaml:"index"`
 		} `yaml:"logs"`
+		Output struct {
+			TimeFormat     string `yaml:"timeFormat,omitempty"`
+			DurationFormat string `yaml:"durationFormat,omitempty"`
+		} `yaml:"output"`
 		Git struct {
 			Sync   bool   `yaml:"sync"`
 			Uri    string `yaml:"uri,omitempty"`
 			Branch string `yaml:"branch,omitempty"`
+			Remote string `yaml:"remote,omitempty"`
 		} `yaml:"git"`
 	} `yaml:"settings"`
 }
@@ -0,0 +1,101 @@
+package logManager
+
+import (
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// This file is used to compare two versions of a week file (Used by sync to show which entries changed)
+
+// WeekChanges holds the log ids of the entries which changed between two versions of a week file
+type WeekChanges struct {
+	Added   []string `json:"added,omitempty"`
+	Changed []string `json:"changed,omitempty"`
+	Removed []string `json:"removed,omitempty"` // Entries which are no longer in the week file (Not entries which were marked as removed)
+}
+
+// Empty checks if none of the entries changed
+func (c WeekChanges) Empty() bool {
+	return len(c.Added) == 0 && len(c.Changed) == 0 && len(c.Removed) == 0
+}
+
+// String describes the changes (I.e added 0123-4, 0123-5; changed 0123-1)
+func (c WeekChanges) String() string {
+	var parts []string
+	for _, change := range []struct {
+		name   string
+		logIds []string
+	}{{"added", c.Added}, {"changed", c.Changed}, {"removed", c.Removed}} {
+		if len(change.logIds) > 0 {
+			parts = append(parts, change.name+" "+strings.Join(change.logIds, ", "))
+		}
+	}
+	if len(parts) == 0 {
+		return "no entries changed"
+	}
+	return strings.Join(parts, "; ")
+}
+
+// IsWeekFile checks if a path relative to the logs path is a week file (I.e 2026/07)
+func IsWeekFile(weekPath string) bool {
+	year, week := path.Split(weekPath)
+	return yearPattern.MatchString(strings.TrimSuffix(year, "/")) && weekPattern.MatchString(week)
+}
+
+// DiffWeekFiles compares the entries of two versions of a week file (Empty data is an empty week, I.e a new week file)
+// Every part of an entry is compared, so edits, the clock, removing, restoring and the tags and project show up as changed
+func DiffWeekFiles(oldData, newData []byte) (WeekChanges, error) {
+
+	var changes WeekChanges
+
+	oldLogFile, err := parseWeekFile(oldData, "old version")
+	if err != nil {
+		return changes, err
+	}
+	newLogFile, err := parseWeekFile(newData, "new version")
+	if err != nil {
+		return changes, err
+	}
+
+	type entryKey struct {
+		monthDay string
+		id       int
+	}
+	var keys []entryKey
+	for _, logFile := range []LogFile{oldLogFile, newLogFile} {
+		for monthDay, ids := range logFile.Log {
+			for id := range ids {
+				if !slices.Contains(keys, entryKey{monthDay, id}) {
+					keys = append(keys, entryKey{monthDay, id})
+				}
+			}
+		}
+	}
+
+	// Sort by log id, so that 0123-10 comes after 0123-9
+	slices.SortFunc(keys, func(a, b entryKey) int {
+		if a.monthDay != b.monthDay {
+			return strings.Compare(a.monthDay, b.monthDay)
+		}
+		return a.id - b.id
+	})
+
+	for _, key := range keys {
+		logId := key.monthDay + "-" + fmt.Sprint(key.id)
+		oldEntry, inOld := oldLogFile.entry(key.monthDay, key.id)
+		newEntry, inNew := newLogFile.entry(key.monthDay, key.id)
+		switch {
+		case !inOld:
+			changes.Added = append(changes.Added, logId)
+		case !inNew:
+			changes.Removed = append(changes.Removed, logId)
+		case !reflect.DeepEqual(oldEntry, newEntry):
+			changes.Changed = append(changes.Changed, logId)
+		}
+	}
+
+	return changes, nil
+}
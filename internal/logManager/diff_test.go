@@ -0,0 +1,62 @@
+package logManager
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDiffWeekFiles(t *testing.T) {
+
+	oldData := `{"Log":{"1017":{"1":"unchanged","2":"edited","3":"removed","9":"dropped"}},"time":{"1017":{"1":{"i":[{"s":100}]}}}}`
+	newData := `{"Log":{"1017":{"1":"unchanged","2":"edited","3":"removed","10":"added"},"1018":{"1":"added"}},` +
+		`"time":{"1017":{"1":{"i":[{"s":100}]}}},` +
+		`"revisions":{"1017":{"2":[{"m":"edited again","t":200}]}},` +
+		`"removed":{"1017":{"3":300}}}`
+
+	tests := []struct {
+		name    string
+		oldData string
+		newData string
+		want    WeekChanges
+	}{
+		{
+			name:    "added, changed and dropped entries",
+			oldData: oldData,
+			newData: newData,
+			want: WeekChanges{
+				Added:   []string{"1017-10", "1018-1"},
+				Changed: []string{"1017-2", "1017-3"},
+				Removed: []string{"1017-9"},
+			},
+		},
+		{
+			name:    "a new week file",
+			newData: `{"Log":{"1017":{"2":"second","1":"first"}}}`,
+			want:    WeekChanges{Added: []string{"1017-1", "1017-2"}},
+		},
+		{
+			name:    "unchanged",
+			oldData: oldData,
+			newData: oldData,
+		},
+	}
+
+	for _, test := range tests {
+		changes, err := DiffWeekFiles([]byte(test.oldData), []byte(test.newData))
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(changes, test.want) {
+			t.Errorf("%s: got %+v, want %+v", test.name, changes, test.want)
+		}
+		if changes.Empty() != (test.name == "unchanged") {
+			t.Errorf("%s: %q is empty: %t", test.name, changes, changes.Empty())
+		}
+	}
+
+	if _, err := DiffWeekFiles([]byte(oldData), []byte(`{"Log":`)); !errors.Is(err, ErrCorruptLogFile) {
+		t.Errorf("got %v, want %v", err, ErrCorruptLogFile)
+	}
+}
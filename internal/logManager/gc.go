@@ -0,0 +1,118 @@
+package logManager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to prune the empty week files created by older versions of worklog
+// (Reading a week used to create it, either empty or as a {"Weeks":{}} placeholder)
+
+// GarbageCollect removes the week files without any entries and the year directories left empty by them
+// It returns the weeks (YYYY/WW) which were removed (Or would be removed with dryRun)
+func GarbageCollect(dryRun bool) ([]string, error) {
+
+	// Lock the logs so that an entry can't be added to a week while it is removed
+	unlock, err := LockLogs()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	weeks, err := weekFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var removedWeeks []string
+	for _, week := range weeks {
+		if !emptyWeekFile(weekFilePath(week)) {
+			continue
+		}
+		removedWeeks = append(removedWeeks, week)
+		if dryRun {
+			continue
+		}
+		log.Debug("Removing empty week: ", week)
+		if err := os.Remove(weekFilePath(week)); err != nil {
+			return removedWeeks[:len(removedWeeks)-1], errors.New("error removing week (" + week + "): " + err.Error())
+		}
+	}
+
+	if dryRun || len(removedWeeks) == 0 {
+		return removedWeeks, nil
+	}
+
+	// Remove the year directories which are now empty
+	yearDirs, err := os.ReadDir(configuration.LogsPath)
+	if err != nil {
+		return removedWeeks, errors.New("error reading logs path (" + configuration.LogsPath + "): " + err.Error())
+	}
+	for _, yearDir := range yearDirs {
+		if !yearDir.IsDir() || !yearPattern.MatchString(yearDir.Name()) {
+			continue
+		}
+		yearPath := filepath.Join(configuration.LogsPath, yearDir.Name())
+		if yearEntries, err := os.ReadDir(yearPath); err == nil && len(yearEntries) == 0 {
+			log.Debug("Removing empty year: ", yearDir.Name())
+			if err := os.Remove(yearPath); err != nil {
+				log.Warn("Unable to remove empty year (", yearPath, "): ", err)
+			}
+		}
+	}
+
+	// Drop the removed weeks from the index
+	for _, week := range removedWeeks {
+		removeIndexWeek(week)
+	}
+
+	return removedWeeks, nil
+}
+
+// emptyWeekFile checks if a week file has no entries (I.e an empty file or a {"Weeks":{}} placeholder)
+// Files which can't be parsed aren't empty, so that they are never removed by mistake
+func emptyWeekFile(logFilePath string) bool {
+
+	data, err := os.ReadFile(logFilePath)
+	if err != nil {
+		return false
+	}
+
+	if len(bytes.TrimSpace(data)) == 0 {
+		return true
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		log.Warn("Skipping week file which can't be parsed (", logFilePath, "): ", err)
+		return false
+	}
+
+	// Only the fields of a log file (Or the placeholder) are expected, anything else is kept
+	var lf LogFile
+	if err := json.Unmarshal(data, &lf); err != nil {
+		log.Warn("Skipping week file which can't be parsed (", logFilePath, "): ", err)
+		return false
+	}
+	for field, value := range fields {
+		switch field {
+		case "Weeks":
+			var weeks map[string]json.RawMessage
+			if json.Unmarshal(value, &weeks) != nil || len(weeks) > 0 {
+				return false
+			}
+		case "Log", "time", "removed", "revisions", "overrides", "metadata":
+		default:
+			return false
+		}
+	}
+
+	return len(lf.Log) == 0 && len(lf.Time) == 0 && len(lf.Removed) == 0 &&
+		len(lf.Revisions) == 0 && len(lf.Overrides) == 0 && len(lf.Metadata) == 0
+}
@@ -0,0 +1,101 @@
+package logManager
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestGarbageCollect checks that only the week files without entries are removed, along with their index and the years they leave empty
+func TestGarbageCollect(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+	testutil.PinClock(t, time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC))
+
+	if _, _, err := Action("add", "kept", "", "", ActionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	weekFiles := map[string]string{
+		"2025/01": `{"Log":{}}`,
+		"2026/38": `{"Other":{}}`,
+		"2026/39": `not json`,
+		"2026/40": ``,
+		"2026/41": `{"Weeks":{}}`,
+	}
+	for week, data := range weekFiles {
+		if err := os.MkdirAll(filepath.Dir(weekFilePath(week)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, weekFilePath(week), data, 0644)
+		if err := os.MkdirAll(filepath.Dir(indexWeekPath(week)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeTestFile(t, indexWeekPath(week), `{}`, 0644)
+	}
+
+	wantRemoved := []string{"2025/01", "2026/40", "2026/41"}
+	wantKept := []string{"2026/38", "2026/39", "2026/42"}
+
+	// A dry run only lists the weeks
+	removed, err := GarbageCollect(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(removed)
+	if !reflect.DeepEqual(removed, wantRemoved) {
+		t.Errorf("dry run would remove %v, want %v", removed, wantRemoved)
+	}
+	for _, week := range append(slices.Clone(wantRemoved), wantKept...) {
+		if _, err := os.Stat(weekFilePath(week)); err != nil {
+			t.Errorf("the dry run removed week %s: %v", week, err)
+		}
+		if _, err := os.Stat(indexWeekPath(week)); err != nil {
+			t.Errorf("the dry run removed the index of week %s: %v", week, err)
+		}
+	}
+
+	removed, err = GarbageCollect(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(removed)
+	if !reflect.DeepEqual(removed, wantRemoved) {
+		t.Errorf("removed %v, want %v", removed, wantRemoved)
+	}
+	for _, week := range wantRemoved {
+		if _, err := os.Stat(weekFilePath(week)); !os.IsNotExist(err) {
+			t.Errorf("week %s was kept", week)
+		}
+		if _, err := os.Stat(indexWeekPath(week)); !os.IsNotExist(err) {
+			t.Errorf("the index of week %s was kept", week)
+		}
+	}
+	for _, week := range wantKept {
+		if _, err := os.Stat(weekFilePath(week)); err != nil {
+			t.Errorf("week %s was removed: %v", week, err)
+		}
+		if _, err := os.Stat(indexWeekPath(week)); err != nil {
+			t.Errorf("the index of week %s was removed: %v", week, err)
+		}
+	}
+
+	// The year without any weeks left is removed
+	if _, err := os.Stat(filepath.Join(configuration.LogsPath, "2025")); !os.IsNotExist(err) {
+		t.Errorf("the empty year 2025 was kept")
+	}
+
+	entries, _, err := Action("get", "", "1017-1", "", ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if message := entries.Entries["1017-1"].Message; message != "kept" {
+		t.Errorf("got %q, want the entry to be kept", message)
+	}
+}
@@ -1,60 +1,272 @@
 package logManager
 
 import (
-	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
 
 	log "github.com/sirupsen/logrus"
 
-	"github.com/mitchs-dev/library-go/processor"
 	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/configuration"
 )
 
-// CreateYearIfNotExist creates a year and week if it does not exist
-func CreateWeekIfNotExist(logFilePath string) {
+var (
+	// yearPattern matches the year directories in the logs path (I.e 2026)
+	yearPattern = regexp.MustCompile(`^\d{4}$`)
 
-	log.Debug("Using log file path: ", logFilePath)
+	// weekPattern matches the week files in a year directory (I.e 07)
+	weekPattern = regexp.MustCompile(`^\d{2}$`)
+)
+
+// parseLogId splits a log id (I.e 0123-4) into the month/day (I.e 0123) and the id for the day (I.e 4)
+func parseLogId(logId string) (string, int, error) {
 
-	// Get the year from the end of the filename.Dir
-	basePath := filepath.Dir(logFilePath)
+	parts := strings.Split(logId, "-")
+	if len(parts) != 2 || len(parts[0]) != 4 {
+		return "", 0, fmt.Errorf("%w (%s), expected format MMDD-N", ErrInvalidLogId, logId)
+	}
+
+	if _, err := strconv.Atoi(parts[0]); err != nil {
+		return "", 0, fmt.Errorf("%w (%s), expected format MMDD-N", ErrInvalidLogId, logId)
+	}
 
-	log.Debug("basePath: ", basePath)
+	id, err := strconv.Atoi(parts[1])
+	if err != nil || id < 1 {
+		return "", 0, fmt.Errorf("%w (%s), expected format MMDD-N", ErrInvalidLogId, logId)
+	}
 
-	// Get the week from the end of the filename
-	weekFile := filepath.Base(logFilePath)
+	return parts[0], id, nil
+}
 
-	log.Debug("weekFile: ", weekFile)
+// weekFilePath returns the path of the week file (YYYY/WW) in the logs path
+func weekFilePath(week string) string {
+	return configuration.LogsPath + "/" + week
+}
 
-	// Check if the year exists
-	if !processor.DirectoryOrFileExists(basePath) {
+// getLogFileForId finds and opens the log file holding the log id and returns the log file, its week (YYYY/WW), the month/day and the id for the day
+func getLogFileForId(logId string) (LogFile, string, string, int, error) {
 
-		// Create the year
-		if !processor.CreateDirectory(basePath) {
-			log.Fatalf("Failed to create basePath %v", basePath)
-		}
-	} else {
-		log.Debugf("basePath %v already exists", basePath)
+	monthDay, id, err := parseLogId(logId)
+	if err != nil {
+		return LogFile{}, "", "", 0, err
+	}
+
+	week, err := calendarManager.MonthDayWeek(monthDay)
+	if err != nil {
+		return LogFile{}, "", "", 0, fmt.Errorf("%w (%s): %v", ErrInvalidLogId, logId, err)
+	}
+
+	log.Debug("Using log file: ", weekFilePath(week))
+
+	var lf LogFile
+	err = lf.GetLogFile(weekFilePath(week))
+	if err != nil {
+		return LogFile{}, "", "", 0, err
+	}
+
+	if _, ok := lf.Log[monthDay][id]; !ok {
+		return LogFile{}, "", "", 0, fmt.Errorf("%w (%s)", ErrEntryNotFound, logId)
+	}
+
+	return lf, week, monthDay, id, nil
+}
+
+// logEntry builds the log entry for an id in the week (YYYY/WW) of the log file
+func (l *LogFile) logEntry(week, monthDay string, id int, now int64) LogEntry {
+	return l.indexEntry(monthDay, id).logEntry(week, now)
+}
+
+// logEntry builds the log entry from its summary in the week (YYYY/WW)
+func (e IndexEntry) logEntry(week string, now int64) LogEntry {
+
+	status := entryStatus(e.Time)
+
+	if e.Removed {
+		status = EntryStatusRemoved
+	}
+
+	var started int64
+	if len(e.Time.Intervals) > 0 {
+		started = e.Time.Intervals[0].Start
+	}
+
+	elapsed := elapsedTime(e.Time, now)
+
+	logEntry := LogEntry{
+		ID:        e.MonthDay + "-" + fmt.Sprint(e.ID),
+		Status:    status,
+		Time:      ConvertTime(started),
+		Worked:    ConvertDuration(elapsed),
+		Elapsed:   elapsed,
+		Started:   started,
+		Message:   e.message(),
+		Tags:      e.Tags,
+		Project:   e.Project,
+		Revisions: append([]Revision{{Message: e.Message, Time: started}}, e.Revisions...),
+	}
+
+	date, err := calendarManager.WeekDayDate(week, e.MonthDay)
+	if err != nil {
+		log.Warn("Unable to find the date of log id (", logEntry.ID, "): ", err)
+		return logEntry
+	}
+	isoYear, isoWeek := date.ISOWeek()
+	logEntry.Date = date.Format("2006-01-02")
+	logEntry.Week = fmt.Sprintf("%d-W%02d", isoYear, isoWeek)
+
+	return logEntry
+}
+
+// addEntry adds a log entry and returns the key it was added under
+// Log ids repeat every year, so an entry with the same log id as one from another year is kept under its date (I.e 2025-10-17/1017-1)
+func (e *LogFileEntries) addEntry(logEntry LogEntry) string {
+	key := logEntry.ID
+	if _, exists := e.Entries[key]; exists {
+		key = logEntry.Date + "/" + logEntry.ID
+	}
+	e.Entries[key] = logEntry
+	return key
+}
+
+// message returns the current message of a log entry (The latest revision, or the original message if it was never edited)
+func (l *LogFile) message(monthDay string, id int) string {
+	if revisions := l.Revisions[monthDay][id]; len(revisions) > 0 {
+		return revisions[len(revisions)-1].Message
+	}
+	return l.Log[monthDay][id]
+}
+
+// isRemoved checks if a log entry has been removed
+func (l *LogFile) isRemoved(monthDay string, id int) bool {
+	_, removed := l.Removed[monthDay][id]
+	return removed
+}
+
+// checkWorkday checks that the action is happening within the workday
+// When it isn't, the action is refused or a warning is logged (settings.schedule.workday.enforcement) unless there is an override reason
+// It returns the override to record against the entry, if one was used
+func checkWorkday(action, overrideReason string) (*Override, error) {
+
+	reason, err := calendarManager.OutsideWorkday(calendarManager.Now())
+	if err != nil {
+		return nil, errors.New("error checking workday: " + err.Error())
+	}
+
+	if reason == "" {
+		return nil, nil
+	}
+
+	if overrideReason != "" {
+		log.Warn("Overriding the workday restriction because ", reason, " (Reason: ", overrideReason, ")")
+		return &Override{
+			Action: action,
+			Reason: overrideReason,
+			Time:   calendarManager.NowEpoch(),
+		}, nil
 	}
 
-	// Check if the week exists
-	if processor.DirectoryOrFileExists(logFilePath) {
-		log.Debugf("logFilePath %v already exists", logFilePath)
+	if configuration.ScheduleWorkdayEnforcement == "refuse" {
+		return nil, fmt.Errorf("%w: cannot %s because %s. Use --override \"<reason>\" if you need to anyway", ErrOutsideWorkday, action, reason)
+	}
+
+	log.Warn("Logging work even though ", reason)
+
+	return nil, nil
+}
+
+// addOverride records an override against a log entry
+func (l *LogFile) addOverride(monthDay string, id int, override *Override) {
+	if override == nil {
 		return
-	} else {
-		// Create the empty week
-		emptyWeek := calendarManager.WeekTree{Weeks: make(map[int]calendarManager.MonthDayTree)}
+	}
+	if l.Overrides == nil {
+		l.Overrides = make(map[string]map[int][]Override)
+	}
+	if l.Overrides[monthDay] == nil {
+		l.Overrides[monthDay] = make(map[int][]Override)
+	}
+	l.Overrides[monthDay][id] = append(l.Overrides[monthDay][id], *override)
+}
 
-		// Marshal the empty week
-		emptyWeekData, err := json.Marshal(emptyWeek)
-		if err != nil {
-			log.Fatal("Error creating week (", logFilePath, "): ", err)
+// entryStatus returns the status of a time entry
+func entryStatus(timeEntry TimeEntry) string {
+	switch {
+	case timeEntry.End != 0:
+		return EntryStatusCompleted
+	case len(timeEntry.Intervals) == 0:
+		return EntryStatusAdded
+	case timeEntry.Intervals[len(timeEntry.Intervals)-1].End != 0:
+		return EntryStatusPaused
+	case len(timeEntry.Intervals) > 1:
+		return EntryStatusResumed
+	default:
+		return EntryStatusStarted
+	}
+}
+
+// totalTime returns the total seconds of the closed intervals
+func totalTime(intervals []TimeInterval) int64 {
+	var total int64
+	for _, interval := range intervals {
+		if interval.End != 0 {
+			total += interval.End - interval.Start
 		}
+	}
+	return total
+}
 
-		// Create the week
-		if !processor.CreateFileAsByte(logFilePath, emptyWeekData) {
-			log.Fatal("Error creating week (", logFilePath, ")")
-		} else {
-			log.Debugf("Created week %v", logFilePath)
+// elapsedTime returns the total seconds worked on a time entry, including the running interval if the entry is still being worked on
+// The running interval only counts until the end of the day it was started on, since entries are started when they are added
+// and most are never paused or ended (I.e an entry added last week which was never ended counts until midnight of that day)
+func elapsedTime(timeEntry TimeEntry, now int64) int64 {
+	elapsed := totalTime(timeEntry.Intervals)
+	if len(timeEntry.Intervals) > 0 && timeEntry.End == 0 {
+		if lastInterval := timeEntry.Intervals[len(timeEntry.Intervals)-1]; lastInterval.End == 0 {
+			end := min(now, calendarManager.EndOfDayEpoch(lastInterval.Start))
+			if end > lastInterval.Start {
+				elapsed += end - lastInterval.Start
+			}
 		}
 	}
+	return elapsed
+}
+
+// weekFiles returns every week (YYYY/WW) with a week file in the logs path, oldest first
+func weekFiles() ([]string, error) {
+
+	yearDirs, err := os.ReadDir(configuration.LogsPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.New("error reading logs path (" + configuration.LogsPath + "): " + err.Error())
+	}
+
+	var weeks []string
+	for _, yearDir := range yearDirs {
+		if !yearDir.IsDir() || !yearPattern.MatchString(yearDir.Name()) {
+			continue
+		}
+		weekEntries, err := os.ReadDir(filepath.Join(configuration.LogsPath, yearDir.Name()))
+		if err != nil {
+			return nil, errors.New("error reading year (" + yearDir.Name() + "): " + err.Error())
+		}
+		for _, weekEntry := range weekEntries {
+			if weekEntry.IsDir() || !weekPattern.MatchString(weekEntry.Name()) {
+				continue
+			}
+			weeks = append(weeks, yearDir.Name()+"/"+weekEntry.Name())
+		}
+	}
+
+	// The names are zero padded, so sorting them as strings sorts them by date
+	slices.Sort(weeks)
+
+	return weeks, nil
 }
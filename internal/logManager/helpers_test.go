@@ -0,0 +1,66 @@
+package logManager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+)
+
+func TestElapsedTime(t *testing.T) {
+
+	calendarManager.SetClock(nil, time.UTC)
+	t.Cleanup(func() { calendarManager.SetClock(nil, nil) })
+
+	at := func(day, hour, minute int) int64 {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC).Unix()
+	}
+	now := at(17, 15, 0)
+
+	tests := []struct {
+		name      string
+		timeEntry TimeEntry
+		want      time.Duration
+	}{
+		{
+			name: "not started",
+			want: 0,
+		},
+		{
+			name:      "running since this morning",
+			timeEntry: TimeEntry{Intervals: []TimeInterval{{Start: at(17, 9, 0)}}},
+			want:      6 * time.Hour,
+		},
+		{
+			name:      "running since six days ago counts until the end of that day",
+			timeEntry: TimeEntry{Intervals: []TimeInterval{{Start: at(11, 14, 30)}}},
+			want:      9*time.Hour + 30*time.Minute,
+		},
+		{
+			name:      "paused",
+			timeEntry: TimeEntry{Intervals: []TimeInterval{{Start: at(11, 9, 0), End: at(11, 10, 0)}}},
+			want:      time.Hour,
+		},
+		{
+			name: "resumed on a later day",
+			timeEntry: TimeEntry{Intervals: []TimeInterval{
+				{Start: at(11, 9, 0), End: at(11, 10, 0)},
+				{Start: at(16, 23, 0)},
+			}},
+			want: 2 * time.Hour,
+		},
+		{
+			name:      "ended",
+			timeEntry: TimeEntry{Intervals: []TimeInterval{{Start: at(11, 9, 0), End: at(12, 10, 0)}}, End: at(12, 10, 0)},
+			want:      25 * time.Hour,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := time.Duration(elapsedTime(test.timeEntry, now)) * time.Second; got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package logManager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to keep an index of the log files so that queries don't have to open and parse every week file
+//
+// The index is a directory next to the logs path with a file per week (I.e logs.index/2026/42) holding a summary of each
+// entry of the week, along with the modification time and size the week file had when it was indexed.
+// Saving a week file only rewrites the index of that week, and list and search only read the weeks they need.
+// A week is only read from the index when the week file still has the same modification time and size,
+// otherwise it is read from the week file and indexed again (So a missing or stale index falls back to the week files)
+
+const (
+	// indexVersion is changed whenever the structure of the index changes, so that older indexes are ignored
+	indexVersion = 2
+)
+
+// IndexWeek holds the index of a week file
+type IndexWeek struct {
+	Version int          `json:"version"`
+	ModTime int64        `json:"modTime"` // The modification time of the week file when it was indexed (Unix nanoseconds)
+	Size    int64        `json:"size"`    // The size of the week file when it was indexed
+	Entries []IndexEntry `json:"entries"`
+}
+
+// IndexEntry holds the summary of a log entry which is needed to list and search it
+type IndexEntry struct {
+	MonthDay  string     `json:"monthDay"`
+	ID        int        `json:"id"`
+	Message   string     `json:"message"` // The original message (The current message is the last revision, if there is one)
+	Revisions []Revision `json:"revisions,omitempty"`
+	Time      TimeEntry  `json:"time"`
+	Removed   bool       `json:"removed,omitempty"`
+	Tags      []string   `json:"tags,omitempty"`
+	Project   string     `json:"project,omitempty"`
+}
+
+// indexPath returns the path of the index
+// It is kept next to the logs path instead of inside it so that it is never synced to Git
+func indexPath() string {
+	return filepath.Clean(configuration.LogsPath) + ".index"
+}
+
+// indexWeekPath returns the path of the index of a week (YYYY/WW)
+func indexWeekPath(week string) string {
+	return filepath.Join(indexPath(), filepath.FromSlash(week))
+}
+
+// indexEntry returns the summary of a log entry
+func (l *LogFile) indexEntry(monthDay string, id int) IndexEntry {
+	return IndexEntry{
+		MonthDay:  monthDay,
+		ID:        id,
+		Message:   l.Log[monthDay][id],
+		Revisions: l.Revisions[monthDay][id],
+		Time:      l.Time[monthDay][id],
+		Removed:   l.isRemoved(monthDay, id),
+		Tags:      l.Metadata[monthDay][id].Tags,
+		Project:   l.Metadata[monthDay][id].Project,
+	}
+}
+
+// indexEntries returns the summaries of every log entry in the log file
+func (l *LogFile) indexEntries() []IndexEntry {
+	var entries []IndexEntry
+	for monthDay := range l.Log {
+		for id := range l.Log[monthDay] {
+			entries = append(entries, l.indexEntry(monthDay, id))
+		}
+	}
+	return entries
+}
+
+// message returns the current message of the entry (The last revision, or the original message if it was never edited)
+func (e IndexEntry) message() string {
+	if len(e.Revisions) > 0 {
+		return e.Revisions[len(e.Revisions)-1].Message
+	}
+	return e.Message
+}
+
+// loadIndexWeek loads the index of the week (YYYY/WW) if it matches the week file
+func loadIndexWeek(week string) ([]IndexEntry, bool) {
+
+	indexData, err := os.ReadFile(indexWeekPath(week))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Debug("Unable to read the index of week (", week, "): ", err)
+		}
+		return nil, false
+	}
+
+	var indexWeek IndexWeek
+	if err := json.Unmarshal(indexData, &indexWeek); err != nil {
+		log.Debug("Unable to parse the index of week (", week, "): ", err)
+		return nil, false
+	}
+
+	if indexWeek.Version != indexVersion {
+		log.Debug("Ignoring index of week (", week, ") with version ", indexWeek.Version, ", expected ", indexVersion)
+		return nil, false
+	}
+
+	fileInfo, err := os.Stat(weekFilePath(week))
+	if err != nil || fileInfo.ModTime().UnixNano() != indexWeek.ModTime || fileInfo.Size() != indexWeek.Size {
+		log.Debug("Index is stale for week: ", week)
+		return nil, false
+	}
+
+	return indexWeek.Entries, true
+}
+
+// saveIndexWeek writes the index of the week (YYYY/WW) with the modification time and size of the week file
+// The week file has to be stat'ed before it is read, so that a change while reading it makes the week stale instead of indexing the old contents
+func saveIndexWeek(week string, entries []IndexEntry, fileInfo os.FileInfo) error {
+
+	indexData, err := json.Marshal(IndexWeek{
+		Version: indexVersion,
+		ModTime: fileInfo.ModTime().UnixNano(),
+		Size:    fileInfo.Size(),
+		Entries: entries,
+	})
+	if err != nil {
+		return errors.New("error marshaling the index of week (" + week + "): " + err.Error())
+	}
+
+	// The first version of the index was a single file, which is replaced by the directory
+	if indexInfo, err := os.Stat(indexPath()); err == nil && !indexInfo.IsDir() {
+		log.Debug("Removing the index of an older version: ", indexPath())
+		if err := os.Remove(indexPath()); err != nil {
+			return errors.New("error removing the index of an older version (" + indexPath() + "): " + err.Error())
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(indexWeekPath(week)), 0755); err != nil {
+		return errors.New("error creating the index directory (" + filepath.Dir(indexWeekPath(week)) + "): " + err.Error())
+	}
+
+	if err := writeFileAtomic(indexWeekPath(week), indexData); err != nil {
+		return errors.New("error saving the index of week (" + week + "): " + err.Error())
+	}
+
+	log.Debug("Index saved for week: ", week)
+
+	return nil
+}
+
+// removeIndexWeek removes the index of a week (YYYY/WW) which no longer has a week file
+func removeIndexWeek(week string) {
+	if err := os.Remove(indexWeekPath(week)); err != nil && !os.IsNotExist(err) {
+		log.Warn("Unable to remove the index of week (", week, "): ", err)
+	}
+}
+
+// updateIndex indexes a week file after it was saved
+// The log file has already been saved, so a failure only means that the week is read from the week file until it is reindexed
+func updateIndex(logFilePath string, lf LogFile) {
+
+	if !configuration.LogsIndex {
+		return
+	}
+
+	week, err := filepath.Rel(filepath.Clean(configuration.LogsPath), filepath.Clean(logFilePath))
+	if err != nil {
+		return
+	}
+	week = filepath.ToSlash(week)
+
+	// The logs are locked while saving, so the week file can't have changed since it was written
+	fileInfo, err := os.Stat(logFilePath)
+	if err != nil {
+		log.Warn("Unable to index week (", week, "): ", err)
+		return
+	}
+
+	if err := saveIndexWeek(week, lf.indexEntries(), fileInfo); err != nil {
+		log.Warn(err)
+	}
+}
+
+// readWeekEntries returns the summaries of the entries of the week (YYYY/WW), from the index when it is up to date
+// A week which is read from its week file is indexed for next time
+// Reading doesn't take the lock, but the index is only used for weeks that still match their week file, so a lost update just means that the week is read from its week file again
+func readWeekEntries(week string) ([]IndexEntry, error) {
+
+	if configuration.LogsIndex {
+		if entries, ok := loadIndexWeek(week); ok {
+			log.Debug("Using index for week: ", week)
+			return entries, nil
+		}
+	}
+
+	fileInfo, statErr := os.Stat(weekFilePath(week))
+
+	var lf LogFile
+	if err := lf.GetLogFile(weekFilePath(week)); err != nil {
+		return nil, err
+	}
+	entries := lf.indexEntries()
+
+	if configuration.LogsIndex && statErr == nil {
+		if err := saveIndexWeek(week, entries, fileInfo); err != nil {
+			log.Warn(err)
+		}
+	}
+
+	return entries, nil
+}
+
+// Reindex rebuilds the index from every week file in the logs path and returns the number of weeks and entries indexed
+func Reindex() (int, int, error) {
+
+	if !configuration.LogsIndex {
+		return 0, 0, fmt.Errorf("%w, enable it with settings.logs.index", ErrIndexDisabled)
+	}
+
+	// Lock the logs so that the week files don't change while they are indexed
+	unlock, err := LockLogs()
+	if err != nil {
+		return 0, 0, err
+	}
+	defer unlock()
+
+	weeks, err := weekFiles()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Start over, so that weeks which no longer have a week file are dropped
+	if err := os.RemoveAll(indexPath()); err != nil {
+		return 0, 0, errors.New("error removing the index (" + indexPath() + "): " + err.Error())
+	}
+
+	var totalEntries int
+	for _, week := range weeks {
+		log.Debug("Indexing week: ", week)
+		fileInfo, err := os.Stat(weekFilePath(week))
+		if err != nil {
+			return 0, 0, errors.New("error indexing week (" + week + "): " + err.Error())
+		}
+		var lf LogFile
+		if err := lf.GetLogFile(weekFilePath(week)); err != nil {
+			return 0, 0, err
+		}
+		entries := lf.indexEntries()
+		if err := saveIndexWeek(week, entries, fileInfo); err != nil {
+			return 0, 0, err
+		}
+		totalEntries += len(entries)
+	}
+
+	return len(weeks), totalEntries, nil
+}
@@ -0,0 +1,119 @@
+package logManager
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestIndex checks that the index is kept per week, is only read for the weeks which are listed, and falls back to week files which changed outside of worklog
+func TestIndex(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+	logsPath := configuration.LogsPath
+
+	// The first version of the index was a single file, which has to be replaced
+	if err := os.WriteFile(logsPath+".index", []byte(`{"version":1,"weeks":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	setNow := func(month time.Month, day int) {
+		testutil.PinClock(t, time.Date(2026, month, day, 9, 0, 0, 0, time.UTC))
+	}
+
+	list := func(period string) []LogEntry {
+		t.Helper()
+		entries, logIds, err := Action("list", "", "", period, ActionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		var listed []LogEntry
+		for _, logId := range logIds {
+			listed = append(listed, entries.Entries[logId])
+		}
+		return listed
+	}
+
+	for _, day := range []int{1, 5, 16} {
+		setNow(time.October, day)
+		if _, _, err := Action("add", "entry #index", "", "", ActionOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each save only indexes its own week
+	for _, week := range []string{"2026/40", "2026/41", "2026/42"} {
+		entries, ok := loadIndexWeek(week)
+		if !ok || len(entries) != 1 {
+			t.Fatalf("index of week %s = %v (%t), want 1 entry", week, entries, ok)
+		}
+	}
+
+	// Listing a week only reads (and indexes) that week
+	if err := os.RemoveAll(indexPath()); err != nil {
+		t.Fatal(err)
+	}
+	if listed := list("today"); len(listed) != 1 || listed[0].ID != "1016-1" || listed[0].Tags[0] != "index" {
+		t.Fatalf("listed %v, want 1016-1 with its tag", listed)
+	}
+	if _, err := os.Stat(indexWeekPath("2026/42")); err != nil {
+		t.Errorf("week 2026/42 was not indexed: %v", err)
+	}
+	for _, week := range []string{"2026/40", "2026/41"} {
+		if _, err := os.Stat(indexWeekPath(week)); !os.IsNotExist(err) {
+			t.Errorf("week %s was indexed, but it wasn't listed", week)
+		}
+	}
+
+	// A week file which changed outside of worklog (I.e after a sync) is read from the week file
+	var lf LogFile
+	if err := lf.GetLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+	lf.Log["1016"][2] = "synced entry"
+	data, err := json.Marshal(lf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logsPath, "2026", "42"), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if listed := list("today"); len(listed) != 2 || listed[1].Message != "synced entry" {
+		t.Fatalf("listed %v, want the synced entry", listed)
+	}
+	if entries, ok := loadIndexWeek("2026/42"); !ok || len(entries) != 2 {
+		t.Errorf("index of week 2026/42 = %v (%t), want the synced entry to be indexed", entries, ok)
+	}
+
+	// Reindexing drops the weeks which no longer have a week file
+	if err := os.MkdirAll(filepath.Dir(indexWeekPath("2025/01")), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(indexWeekPath("2025/01"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	weeks, entries, err := Reindex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if weeks != 3 || entries != 4 {
+		t.Errorf("reindexed %d weeks and %d entries, want 3 and 4", weeks, entries)
+	}
+	if _, err := os.Stat(indexWeekPath("2025/01")); !os.IsNotExist(err) {
+		t.Errorf("the index of a week without a week file was kept")
+	}
+
+	// Search reads the index as well
+	found, logIds, err := Search(SearchQuery{Text: "synced"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logIds) != 1 || found.Entries[logIds[0]].ID != "1016-2" {
+		t.Errorf("found %v, want 1016-2", logIds)
+	}
+}
@@ -0,0 +1,81 @@
+package logManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to lock the logs while they are being changed
+// so that concurrent worklog commands can't pick the same id or overwrite each other
+
+var (
+	// LockTimeout is how long to wait for another worklog command to release the lock
+	LockTimeout = 10 * time.Second
+
+	// lockRetryInterval is how often to retry the lock while waiting
+	lockRetryInterval = 25 * time.Millisecond
+)
+
+// lockFilePath returns the path of the lock file
+// It is kept next to the logs path instead of inside it so that it is never synced to Git
+func lockFilePath() string {
+	return filepath.Clean(configuration.LogsPath) + ".lock"
+}
+
+// LockLogs takes an exclusive advisory lock on the logs and returns a function to release it
+// Every command which changes the logs holds it, so that concurrent commands can't overwrite each other
+// The lock is released by the operating system if the process exits without releasing it
+func LockLogs() (func(), error) {
+
+	lockPath := lockFilePath()
+
+	if err := os.MkdirAll(filepath.Dir(lockPath), 0755); err != nil {
+		return nil, errors.New("error creating lock directory (" + filepath.Dir(lockPath) + "): " + err.Error())
+	}
+
+	lockFile, err := os.OpenFile(lockPath, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, errors.New("error opening lock file (" + lockPath + "): " + err.Error())
+	}
+
+	deadline := time.Now().Add(LockTimeout)
+	for {
+		locked, err := tryLockFile(lockFile)
+		if err != nil {
+			lockFile.Close()
+			return nil, errors.New("error locking logs (" + lockPath + "): " + err.Error())
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			lockFile.Close()
+			return nil, fmt.Errorf("%w after %s, another worklog command is still running (lock: %s)", ErrLockTimeout, LockTimeout, lockPath)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+
+	log.Debug("Locked logs: ", lockPath)
+
+	unlock := func() {
+		if err := unlockFile(lockFile); err != nil {
+			log.Warn("Error unlocking logs (", lockPath, "): ", err)
+		}
+		lockFile.Close()
+		log.Debug("Unlocked logs: ", lockPath)
+	}
+
+	// No other command can be saving now, so anything left behind is from a save which was interrupted
+	if err := recoverLogs(); err != nil {
+		unlock()
+		return nil, err
+	}
+
+	return unlock, nil
+}
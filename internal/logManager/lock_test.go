@@ -0,0 +1,83 @@
+package logManager
+
+import (
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestConcurrentAdd adds entries from many goroutines while others list them
+// Every add has to get its own log id and end up in the week file, and no temporary files may be left behind
+func TestConcurrentAdd(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+	logsPath := configuration.LogsPath
+
+	const adders, readers = 40, 40
+
+	var wg sync.WaitGroup
+	errs := make(chan error, adders+readers)
+	logIds := make(chan string, adders)
+
+	for i := 0; i < adders; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, ids, err := Action("add", fmt.Sprintf("entry %d", i), "", "", ActionOptions{})
+			if err != nil {
+				errs <- fmt.Errorf("add %d: %w", i, err)
+				return
+			}
+			logIds <- ids[0]
+		}(i)
+	}
+
+	for i := 0; i < readers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, _, err := Action("list", "", "", "today", ActionOptions{}); err != nil {
+				errs <- fmt.Errorf("list %d: %w", i, err)
+			}
+		}(i)
+	}
+
+	wg.Wait()
+	close(errs)
+	close(logIds)
+
+	for err := range errs {
+		t.Error(err)
+	}
+
+	seen := make(map[string]bool)
+	for logId := range logIds {
+		if seen[logId] {
+			t.Errorf("log id %s was given to more than one entry", logId)
+		}
+		seen[logId] = true
+	}
+	if len(seen) != adders {
+		t.Errorf("got %d log ids, want %d", len(seen), adders)
+	}
+
+	entries, _, err := Action("list", "", "", "today", ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries.Entries) != adders {
+		t.Errorf("listed %d entries, want %d", len(entries.Entries), adders)
+	}
+
+	leftovers, err := filepath.Glob(filepath.Join(logsPath, "*", "*"+tempPattern))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(leftovers) > 0 {
+		t.Errorf("temporary files were left behind: %v", leftovers)
+	}
+}
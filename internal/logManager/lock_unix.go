@@ -0,0 +1,23 @@
+//go:build !windows
+
+package logManager
+
+import (
+	"errors"
+	"os"
+	"syscall"
+)
+
+// tryLockFile tries to take an exclusive lock on the file without blocking
+func tryLockFile(file *os.File) (bool, error) {
+	err := syscall.Flock(int(file.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+	if errors.Is(err, syscall.EWOULDBLOCK) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// unlockFile releases the lock on the file
+func unlockFile(file *os.File) error {
+	return syscall.Flock(int(file.Fd()), syscall.LOCK_UN)
+}
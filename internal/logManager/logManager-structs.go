@@ -4,12 +4,55 @@ package logManager
 
 // LogFile holds the structure of the log file
 type LogFile struct {
-	Log  map[string]map[int]string    `json:"Log"`
-	Time map[string]map[int]TimeEntry `json:"time,omitempty"`
+	Log       map[string]map[int]string     `json:"Log"`
+	Time      map[string]map[int]TimeEntry  `json:"time,omitempty"`
+	Removed   map[string]map[int]int64      `json:"removed,omitempty"`
+	Revisions map[string]map[int][]Revision `json:"revisions,omitempty"`
+	Overrides map[string]map[int][]Override `json:"overrides,omitempty"`
+	Metadata  map[string]map[int]Metadata   `json:"metadata,omitempty"`
+}
+
+// ActionOptions holds the options of an action which only some of the actions use
+type ActionOptions struct {
+	Metadata Metadata // The tags and project to add with a new entry (Set with --tag and --project)
+	Override string   // The reason for changing the logs outside of the workday (Set with --override)
+}
+
+// Metadata holds the tags and project of a log entry (From --tag/--project and the inline #tag/@project tokens)
+type Metadata struct {
+	Tags    []string `json:"tags,omitempty" yaml:"Tags,omitempty"`
+	Project string   `json:"project,omitempty" yaml:"Project,omitempty"`
+}
+
+// Override holds the reason an action was allowed outside of the workday
+type Override struct {
+	Action string `json:"a"`
+	Reason string `json:"r"`
+	Time   int64  `json:"t"`
+}
+
+// Revision holds an edit of a log entry (The original message is kept in Log)
+type Revision struct {
+	Message string `json:"m"`
+	Time    int64  `json:"t"`
 }
 
 // TimeEntry holds the time entries of the logs
 type TimeEntry struct {
+	Intervals []TimeInterval `json:"i,omitempty"`
+	End       int64          `json:"e,omitempty"`
+	Total     int64          `json:"t,omitempty"`
+}
+
+// TimeInterval holds a single period of work on an entry (An interval with no end is still running)
+type TimeInterval struct {
+	Start int64 `json:"s"`
+	End   int64 `json:"e,omitempty"`
+}
+
+// legacyTimeEntry holds the original (flat) structure of the time entries
+// which only had room for a single pause/resume
+type legacyTimeEntry struct {
 	Start  int64 `json:"s,omitempty"`
 	Pause  int64 `json:"p,omitempty"`
 	Resume int64 `json:"r,omitempty"`
@@ -19,9 +62,18 @@ type TimeEntry struct {
 
 // LogEntry represents a single entry in the log
 type LogEntry struct {
-	Status  string `yaml:"Status"`
-	Time    string `yaml:"Time"`
-	Message string `yaml:"Message"`
+	ID        string     `yaml:"ID"`
+	Date      string     `yaml:"Date"`
+	Week      string     `yaml:"Week"`
+	Status    string     `yaml:"Status"`
+	Time      string     `yaml:"Time"`    // The wall-clock time the entry was logged (I.e 14:32)
+	Worked    string     `yaml:"Worked"`  // The time worked on the entry (I.e 1h25m)
+	Elapsed   int64      `yaml:"Elapsed"` // The time worked on the entry in seconds
+	Started   int64      `yaml:"Started"` // The epoch time the entry was logged
+	Message   string     `yaml:"Message"`
+	Tags      []string   `yaml:"Tags,omitempty"`
+	Project   string     `yaml:"Project,omitempty"`
+	Revisions []Revision `yaml:"Revisions,omitempty"`
 }
 
 // LogFileEntries holds the structure of the log file entries
@@ -1,5 +1,7 @@
 package logManager
 
+import "errors"
+
 // This file holds the variables associated with the log manager
 
 // Entry statuses
@@ -9,4 +11,24 @@ var (
 	EntryStatusPaused    = "paused"
 	EntryStatusResumed   = "resumed"
 	EntryStatusCompleted = "completed"
+	EntryStatusRemoved   = "removed"
+)
+
+// Errors returned by the log manager (Check for them with errors.Is)
+var (
+	ErrInvalidAction       = errors.New("invalid action")
+	ErrInvalidLogId        = errors.New("invalid log id")
+	ErrEntryNotFound       = errors.New("entry not found")
+	ErrEntryRemoved        = errors.New("entry has been removed")
+	ErrEntryNotRemoved     = errors.New("entry has not been removed")
+	ErrRestoreWindowPassed = errors.New("restore window has passed")
+	ErrInvalidTransition   = errors.New("invalid time transition")
+	ErrEmptyMessage        = errors.New("log message is empty")
+	ErrUnchangedMessage    = errors.New("log message is unchanged")
+	ErrInvalidMetadata     = errors.New("invalid tag or project")
+	ErrInvalidQuery        = errors.New("invalid search query")
+	ErrIndexDisabled       = errors.New("the index is disabled")
+	ErrOutsideWorkday      = errors.New("outside of the workday")
+	ErrCorruptLogFile      = errors.New("corrupt log file")
+	ErrLockTimeout         = errors.New("timed out waiting for the lock")
 )
@@ -1,11 +1,11 @@
 package logManager
 
 import (
+	"errors"
 	"fmt"
-	"path/filepath"
+	"slices"
 
-	"github.com/mitchs-dev/library-go/generator"
-	"github.com/mitchs-dev/library-go/processor"
+	"github.com/mitchs-dev/library-go/customTime"
 	"github.com/mitchs-dev/worklog/internal/calendarManager"
 	"github.com/mitchs-dev/worklog/internal/configuration"
 	log "github.com/sirupsen/logrus"
@@ -16,10 +16,12 @@ var (
 		// Basic actions
 		"add",
 		"remove",
+		"restore",
 		"list",
-		// Code actions (To be implemented)
+		"get",
+		// Code actions
 		"edit",
-		// Time actions (To be implemented)
+		// Time actions
 		"start",
 		"pause",
 		"resume",
@@ -38,66 +40,75 @@ func validateAction(action string) bool {
 }
 
 // Action is the main function for the log manager
-func Action(action, logMessage, logId string, period string) (LogFileEntries, []string) {
+func Action(action, logMessage, logId string, period string, options ActionOptions) (LogFileEntries, []string, error) {
 
 	if !validateAction(action) {
-		log.Fatal("Invalid action: ", action)
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrInvalidAction, action)
 	}
 
 	switch action {
 	case "add":
-		return actionAdd(logMessage)
+		return actionAdd(logMessage, options)
 	case "remove":
 		return actionRemove(logId)
+	case "restore":
+		return actionRestore(logId)
 	case "list":
 		return actionList(period)
+	case "get":
+		return actionGet(logId)
 	case "edit":
-		// To be implemented
-	case "start":
-		// To be implemented
-	case "pause":
-		// To be implemented
-	case "resume":
-		// To be implemented
-	case "end":
-		// To be implemented
+		return actionEdit(logMessage, logId)
+	case "start", "pause", "resume", "end":
+		return actionTime(action, logId, options.Override)
 	}
 
-	return LogFileEntries{}, nil
+	return LogFileEntries{}, nil, nil
 
 }
 
 // actionAdd adds a log entry
-func actionAdd(logMessage string) (LogFileEntries, []string) {
+func actionAdd(logMessage string, options ActionOptions) (LogFileEntries, []string, error) {
 
-	dirs, _, _, today, err := calendarManager.PeriodFetch("today")
+	if logMessage == "" {
+		return LogFileEntries{}, nil, ErrEmptyMessage
+	}
+
+	// The --tag/--project metadata takes precedence over the inline #tag/@project tokens
+	metadata, err := mergeMetadata(parseMetadata(logMessage), options.Metadata)
 	if err != nil {
-		log.Fatal("Error fetching period: ", err)
+		return LogFileEntries{}, nil, err
 	}
 
-	if len(dirs) > 1 {
-		log.Fatal("Multiple weeks returned but expected only one")
+	override, err := checkWorkday("add", options.Override)
+	if err != nil {
+		return LogFileEntries{}, nil, err
 	}
 
-	logFilePath := configuration.LogsPath + "/" + dirs[0]
+	unlock, err := LockLogs()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	defer unlock()
 
-	log.Debug("Using log file: ", logFilePath)
+	dirs, _, _, today, err := calendarManager.PeriodFetch("today")
+	if err != nil {
+		return LogFileEntries{}, nil, errors.New("error fetching period: " + err.Error())
+	}
 
-	logFileDir := filepath.Dir(logFilePath)
+	if len(dirs) > 1 {
+		return LogFileEntries{}, nil, errors.New("multiple weeks returned but expected only one")
+	}
 
-	log.Debug("Log file directory: ", logFileDir)
+	logFilePath := weekFilePath(dirs[0])
 
-	// Create the log file directory if it doesn't exist
-	log.Debug("Checking if log file directory exists: ", logFileDir)
-	if !processor.DirectoryOrFileExists(logFileDir) {
-		if !processor.CreateDirectory(logFileDir) {
-			log.Fatal("Error creating log file directory: ", logFileDir)
-		}
-		log.Debug("Created log file directory: ", logFileDir)
-	}
+	log.Debug("Using log file: ", logFilePath)
 
 	var lf LogFile
-	lf.GetLogFile(logFilePath)
+	err = lf.GetLogFile(logFilePath)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
 
 	// Find the highest log id for the day
 	var highestLogId int
@@ -128,46 +139,290 @@ func actionAdd(logMessage string) (LogFileEntries, []string) {
 
 	// Add the log entry
 	lf.Log[today][newLogId] = logMessage
+	lf.addOverride(today, newLogId, override)
+	lf.setMetadata(today, newLogId, metadata)
 
 	// We also need to set the time entry
 	lf.Time[today][newLogId] = TimeEntry{
-		Start:  int64(generator.EpochTimestamp(configuration.ScheduleWorkdayTimezone)),
-		Pause:  0,
-		Resume: 0,
-		End:    0,
-		Total:  0,
+		Intervals: []TimeInterval{
+			{Start: calendarManager.NowEpoch()},
+		},
 	}
 
 	// Save the log file
 	err = lf.SaveLogFile(logFilePath)
 	if err != nil {
-		log.Fatal("Error saving log file: ", err)
+		return LogFileEntries{}, nil, err
 	}
 
+	logEntry := lf.logEntry(dirs[0], today, newLogId, calendarManager.NowEpoch())
+	logEntry.Status = EntryStatusAdded
+
 	return LogFileEntries{
 		Entries: map[string]LogEntry{
-			today + "-" + fmt.Sprint(newLogId): {
-				Status:  EntryStatusAdded,
-				Time:    ConvertTime(lf.Time[today][newLogId].Total),
-				Message: logMessage,
-			},
+			logEntry.ID: logEntry,
 		},
-	}, []string{today + "-" + fmt.Sprint(newLogId)}
+	}, []string{logEntry.ID}, nil
 
 }
 
 // actionRemove removes a log entry
-func actionRemove(logId string) (LogFileEntries, []string) {
+// The entry is only marked as removed so that it can be restored within the restore window
+func actionRemove(logId string) (LogFileEntries, []string, error) {
+
+	unlock, err := LockLogs()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	defer unlock()
+
+	lf, week, monthDay, id, err := getLogFileForId(logId)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	if lf.isRemoved(monthDay, id) {
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrEntryRemoved, logId)
+	}
+
+	if lf.Removed == nil {
+		lf.Removed = make(map[string]map[int]int64)
+	}
+	if lf.Removed[monthDay] == nil {
+		lf.Removed[monthDay] = make(map[int]int64)
+	}
+
+	lf.Removed[monthDay][id] = calendarManager.NowEpoch()
+
+	// Save the log file
+	err = lf.SaveLogFile(weekFilePath(week))
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
 
-	return LogFileEntries{}, nil
+	return LogFileEntries{
+		Entries: map[string]LogEntry{
+			logId: lf.logEntry(week, monthDay, id, calendarManager.NowEpoch()),
+		},
+	}, []string{logId}, nil
+
+}
+
+// actionRestore restores a removed log entry if it is still within the restore window
+func actionRestore(logId string) (LogFileEntries, []string, error) {
+
+	unlock, err := LockLogs()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	defer unlock()
+
+	lf, week, monthDay, id, err := getLogFileForId(logId)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	if !lf.isRemoved(monthDay, id) {
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrEntryNotRemoved, logId)
+	}
+
+	if configuration.LogsRestoreWindow != "" {
+		restoreWindow, err := customTime.ParseDuration(configuration.LogsRestoreWindow)
+		if err != nil {
+			return LogFileEntries{}, nil, errors.New("error parsing restore window (" + configuration.LogsRestoreWindow + "): " + err.Error())
+		}
+		removedAt := calendarManager.FromEpoch(lf.Removed[monthDay][id])
+		if calendarManager.Now().Sub(removedAt) > restoreWindow {
+			return LogFileEntries{}, nil, fmt.Errorf("%w: log id (%s) was removed more than %s ago", ErrRestoreWindowPassed, logId, configuration.LogsRestoreWindow)
+		}
+	}
+
+	delete(lf.Removed[monthDay], id)
+	if len(lf.Removed[monthDay]) == 0 {
+		delete(lf.Removed, monthDay)
+	}
+
+	// Save the log file
+	err = lf.SaveLogFile(weekFilePath(week))
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return LogFileEntries{
+		Entries: map[string]LogEntry{
+			logId: lf.logEntry(week, monthDay, id, calendarManager.NowEpoch()),
+		},
+	}, []string{logId}, nil
+
+}
+
+// actionGet gets a single log entry
+func actionGet(logId string) (LogFileEntries, []string, error) {
+
+	lf, week, monthDay, id, err := getLogFileForId(logId)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return LogFileEntries{
+		Entries: map[string]LogEntry{
+			logId: lf.logEntry(week, monthDay, id, calendarManager.NowEpoch()),
+		},
+	}, []string{logId}, nil
 
 }
 
-func actionList(period string) (LogFileEntries, []string) {
+// actionEdit edits a log entry
+// The original message is never changed, instead each edit is appended as a revision
+func actionEdit(logMessage, logId string) (LogFileEntries, []string, error) {
+
+	if logMessage == "" {
+		return LogFileEntries{}, nil, ErrEmptyMessage
+	}
+
+	unlock, err := LockLogs()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	defer unlock()
+
+	lf, week, monthDay, id, err := getLogFileForId(logId)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	if lf.isRemoved(monthDay, id) {
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrEntryRemoved, logId)
+	}
+
+	if logMessage == lf.message(monthDay, id) {
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrUnchangedMessage, logId)
+	}
+
+	if lf.Revisions == nil {
+		lf.Revisions = make(map[string]map[int][]Revision)
+	}
+	if lf.Revisions[monthDay] == nil {
+		lf.Revisions[monthDay] = make(map[int][]Revision)
+	}
+
+	lf.Revisions[monthDay][id] = append(lf.Revisions[monthDay][id], Revision{
+		Message: logMessage,
+		Time:    calendarManager.NowEpoch(),
+	})
+
+	// Edits can add inline #tag/@project tokens, but the existing metadata is kept
+	metadata, err := mergeMetadata(lf.Metadata[monthDay][id], parseMetadata(logMessage))
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	lf.setMetadata(monthDay, id, metadata)
+
+	// Save the log file
+	err = lf.SaveLogFile(weekFilePath(week))
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return LogFileEntries{
+		Entries: map[string]LogEntry{
+			logId: lf.logEntry(week, monthDay, id, calendarManager.NowEpoch()),
+		},
+	}, []string{logId}, nil
+
+}
+
+// actionTime applies a time action (start, pause, resume, end) to a log entry
+func actionTime(action, logId, overrideReason string) (LogFileEntries, []string, error) {
+
+	unlock, err := LockLogs()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+	defer unlock()
+
+	lf, week, monthDay, id, err := getLogFileForId(logId)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	if lf.isRemoved(monthDay, id) {
+		return LogFileEntries{}, nil, fmt.Errorf("%w (%s)", ErrEntryRemoved, logId)
+	}
+
+	if lf.Time == nil {
+		lf.Time = make(map[string]map[int]TimeEntry)
+	}
+	if lf.Time[monthDay] == nil {
+		lf.Time[monthDay] = make(map[int]TimeEntry)
+	}
+
+	timeEntry := lf.Time[monthDay][id]
+	status := entryStatus(timeEntry)
+	now := calendarManager.NowEpoch()
+
+	log.Debug("Current status of ", logId, ": ", status)
+
+	// Only these statuses can be moved on by each action
+	// An entry is only added (Without a time) when it was logged before worklog tracked time, since new entries are started when they are added
+	// Starting an entry which was already started is rejected, so that the time already worked on it is never replaced
+	allowedStatuses := map[string][]string{
+		"start":  {EntryStatusAdded},
+		"pause":  {EntryStatusStarted, EntryStatusResumed},
+		"resume": {EntryStatusPaused},
+		"end":    {EntryStatusAdded, EntryStatusStarted, EntryStatusPaused, EntryStatusResumed},
+	}
+	var allowed bool
+	for _, allowedStatus := range allowedStatuses[action] {
+		if status == allowedStatus {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return LogFileEntries{}, nil, fmt.Errorf("%w: cannot %s log id (%s) because it is %s", ErrInvalidTransition, action, logId, status)
+	}
+
+	switch action {
+	case "start", "resume":
+		override, err := checkWorkday(action, overrideReason)
+		if err != nil {
+			return LogFileEntries{}, nil, err
+		}
+		lf.addOverride(monthDay, id, override)
+		timeEntry.Intervals = append(timeEntry.Intervals, TimeInterval{Start: now})
+	case "pause":
+		timeEntry.Intervals[len(timeEntry.Intervals)-1].End = now
+	case "end":
+		if status == EntryStatusStarted || status == EntryStatusResumed {
+			timeEntry.Intervals[len(timeEntry.Intervals)-1].End = now
+		}
+		timeEntry.End = now
+	}
+
+	timeEntry.Total = totalTime(timeEntry.Intervals)
+
+	lf.Time[monthDay][id] = timeEntry
+
+	// Save the log file
+	err = lf.SaveLogFile(weekFilePath(week))
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return LogFileEntries{
+		Entries: map[string]LogEntry{
+			logId: lf.logEntry(week, monthDay, id, now),
+		},
+	}, []string{logId}, nil
+
+}
+
+func actionList(period string) (LogFileEntries, []string, error) {
 
 	_, useYearTree, start, end, err := calendarManager.PeriodFetch(period)
 	if err != nil {
-		log.Fatal("Error fetching period: ", err)
+		return LogFileEntries{}, nil, err
 	}
 
 	log.Debug("Period: ", period)
@@ -180,6 +435,8 @@ func actionList(period string) (LogFileEntries, []string) {
 	}
 	var entryIDs []string
 
+	now := calendarManager.NowEpoch()
+
 	for year := range useYearTree.Years {
 		log.Debug("Iterating year: ", year)
 		for week := range useYearTree.Years[year].Weeks {
@@ -188,45 +445,30 @@ func actionList(period string) (LogFileEntries, []string) {
 			if len(weekStr) == 1 {
 				weekStr = "0" + fmt.Sprint(week)
 			}
-			fileName := configuration.LogsPath + "/" + fmt.Sprintf("%s/%s", fmt.Sprint(year), weekStr)
-			log.Debug("Using log file: ", fileName)
-			var lf LogFile
-			lf.GetLogFile(fileName)
-			log.Debug("Month days: ", useYearTree.Years[year].Weeks[week].MonthDays)
-			for monthDayIndex, _ := range useYearTree.Years[year].Weeks[week].MonthDays {
-				monthDay := useYearTree.Years[year].Weeks[week].MonthDays[monthDayIndex]
-				monthDayStr := fmt.Sprint(monthDay)
-				log.Debug("Iterating month day: ", monthDay)
-				totalEntries := len(lf.Log[monthDayStr])
-				log.Debug("Total entries: ", totalEntries)
-				for logId := range lf.Log[monthDayStr] {
-					log.Debug("Iterating log id: ", logId)
-					var status string
-					var timeStr string
-					if lf.Time[monthDayStr][logId].End != 0 {
-						status = EntryStatusCompleted
-						timeStr = ConvertTime(lf.Time[monthDayStr][logId].Total)
-					} else if lf.Time[monthDayStr][logId].Resume != 0 {
-						status = EntryStatusResumed
-						timeStr = ConvertTime(lf.Time[monthDayStr][logId].Resume - lf.Time[monthDayStr][logId].Start)
-					} else if lf.Time[monthDayStr][logId].Pause != 0 {
-						status = EntryStatusPaused
-						timeStr = ConvertTime(lf.Time[monthDayStr][logId].Pause)
-					} else {
-						status = EntryStatusStarted
-						timeStr = ConvertTime(lf.Time[monthDayStr][logId].Start)
-					}
-					entries.Entries[monthDayStr+"-"+fmt.Sprint(logId)] = LogEntry{
-						Status:  status,
-						Time:    timeStr,
-						Message: lf.Log[monthDayStr][logId],
-					}
-					entryIDs = append(entryIDs, monthDayStr+"-"+fmt.Sprint(logId))
+			log.Debug("Using log file: ", weekFilePath(fmt.Sprint(year)+"/"+weekStr))
+			weekEntries, err := readWeekEntries(fmt.Sprint(year) + "/" + weekStr)
+			if err != nil {
+				return LogFileEntries{}, nil, err
+			}
+			monthDays := useYearTree.Years[year].Weeks[week].MonthDays
+			log.Debug("Month days: ", monthDays)
+			for _, indexEntry := range weekEntries {
+				if !slices.Contains(monthDays, indexEntry.MonthDay) {
+					continue
 				}
+				log.Debug("Iterating log id: ", indexEntry.MonthDay, "-", indexEntry.ID)
+				logEntry := indexEntry.logEntry(fmt.Sprint(year)+"/"+weekStr, now)
+				entryIDs = append(entryIDs, entries.addEntry(logEntry))
 			}
 		}
 
 	}
 
-	return entries, entryIDs
+	// Map iteration order is random, so sort the entries to list them in the same order every time
+	entryIDs, err = SortLogIds(entries, entryIDs, "", false)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return entries, entryIDs, nil
 }
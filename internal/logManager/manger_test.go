@@ -0,0 +1,304 @@
+package logManager
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestListSameDayInTwoYears lists a range with the same calendar day (and so the same log id) in two years
+func TestListSameDayInTwoYears(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	for _, year := range []int{2025, 2026} {
+		testutil.PinClock(t, time.Date(year, time.October, 17, 9, 0, 0, 0, time.UTC))
+		if _, _, err := Action("add", "entry in "+fmt.Sprint(year), "", "", ActionOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, logIds, err := Action("list", "", "", "2025-10-01..2026-10-31", ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logIds) != 2 || len(entries.Entries) != 2 {
+		t.Fatalf("got %d log ids and %d entries, want 2 of each: %v", len(logIds), len(entries.Entries), logIds)
+	}
+
+	for i, want := range []string{"entry in 2025", "entry in 2026"} {
+		entry := entries.Entries[logIds[i]]
+		if entry.ID != "1017-1" || entry.Message != want {
+			t.Errorf("entry %d = %s %q, want 1017-1 %q", i, entry.ID, entry.Message, want)
+		}
+	}
+}
+
+// TestTimeActions checks that start, pause, resume and end move an entry through its statuses and keep every interval worked
+func TestTimeActions(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	at := func(hour, minute int) int64 {
+		return time.Date(2026, time.October, 17, hour, minute, 0, 0, time.UTC).Unix()
+	}
+	action := func(action, logId string, hour, minute int) (LogEntry, error) {
+		t.Helper()
+		testutil.PinClock(t, time.Unix(at(hour, minute), 0))
+		entries, _, err := Action(action, "", logId, "", ActionOptions{})
+		return entries.Entries[logId], err
+	}
+
+	// An entry logged before worklog tracked time has no time, so it is only added
+	lf := LogFile{Log: map[string]map[int]string{"1017": {1: "logged before time tracking"}}}
+	if err := lf.SaveLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+
+	steps := []struct {
+		action     string
+		hour       int
+		minute     int
+		wantStatus string
+	}{
+		{"start", 9, 0, EntryStatusStarted},
+		{"pause", 10, 0, EntryStatusPaused},
+		{"resume", 11, 0, EntryStatusResumed},
+		{"pause", 11, 30, EntryStatusPaused},
+		{"resume", 12, 0, EntryStatusResumed},
+		{"end", 13, 0, EntryStatusCompleted},
+	}
+	for _, step := range steps {
+		entry, err := action(step.action, "1017-1", step.hour, step.minute)
+		if err != nil {
+			t.Fatalf("%s: %v", step.action, err)
+		}
+		if entry.Status != step.wantStatus {
+			t.Errorf("%s: status %s, want %s", step.action, entry.Status, step.wantStatus)
+		}
+	}
+
+	var saved LogFile
+	if err := saved.GetLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+	want := TimeEntry{
+		Intervals: []TimeInterval{
+			{Start: at(9, 0), End: at(10, 0)},
+			{Start: at(11, 0), End: at(11, 30)},
+			{Start: at(12, 0), End: at(13, 0)},
+		},
+		End:   at(13, 0),
+		Total: int64((2*time.Hour + 30*time.Minute).Seconds()),
+	}
+	if got := saved.Time["1017"][1]; !reflect.DeepEqual(got, want) {
+		t.Errorf("time %+v, want %+v", got, want)
+	}
+}
+
+// TestTimeActionsRejectInvalidTransitions checks that an action which doesn't apply to the status of the entry is rejected and changes nothing
+func TestTimeActionsRejectInvalidTransitions(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+	testutil.PinClock(t, time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC))
+
+	action := func(action, logId string) error {
+		_, _, err := Action(action, "", logId, "", ActionOptions{})
+		return err
+	}
+	add := func() string {
+		t.Helper()
+		_, logIds, err := Action("add", "entry", "", "", ActionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return logIds[0]
+	}
+
+	tests := []struct {
+		name   string
+		before []string // The actions which bring the entry to the status under test
+		action string
+	}{
+		{"start a started entry", nil, "start"},
+		{"resume a started entry", nil, "resume"},
+		{"start a paused entry", []string{"pause"}, "start"},
+		{"pause a paused entry", []string{"pause"}, "pause"},
+		{"start a resumed entry", []string{"pause", "resume"}, "start"},
+		{"resume a resumed entry", []string{"pause", "resume"}, "resume"},
+		{"pause a completed entry", []string{"end"}, "pause"},
+		{"resume a completed entry", []string{"end"}, "resume"},
+		{"end a completed entry", []string{"end"}, "end"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			logId := add()
+			for _, before := range test.before {
+				if err := action(before, logId); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var lf LogFile
+			if err := lf.GetLogFile(weekFilePath("2026/42")); err != nil {
+				t.Fatal(err)
+			}
+			timeBefore := lf.Time["1017"]
+
+			if err := action(test.action, logId); !errors.Is(err, ErrInvalidTransition) {
+				t.Fatalf("got %v, want %v", err, ErrInvalidTransition)
+			}
+
+			var after LogFile
+			if err := after.GetLogFile(weekFilePath("2026/42")); err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(after.Time["1017"], timeBefore) {
+				t.Errorf("the rejected %s changed the time of %s", test.action, logId)
+			}
+		})
+	}
+}
+
+// TestRemoveAndRestore checks that a removed entry can only be restored within the restore window
+func TestRemoveAndRestore(t *testing.T) {
+
+	removedAt := time.Date(2026, time.October, 17, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		restoreWindow string
+		remove        bool          // Whether the entry is removed before it is restored
+		restoreAfter  time.Duration // How long after removing it the entry is restored
+		wantErr       error
+	}{
+		{"inside the window", "1d", true, time.Hour, nil},
+		{"at the end of the window", "1d", true, 24*time.Hour - time.Minute, nil},
+		{"after the window", "1d", true, 24*time.Hour + time.Minute, ErrRestoreWindowPassed},
+		{"without a window", "", true, 30 * 24 * time.Hour, nil},
+		{"an entry which isn't removed", "1d", false, time.Hour, ErrEntryNotRemoved},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			testutil.LoadConfig(t, testutil.Options{})
+			configuration.LogsRestoreWindow = test.restoreWindow
+
+			testutil.PinClock(t, removedAt.Add(-time.Hour))
+			_, logIds, err := Action("add", "entry", "", "", ActionOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			logId := logIds[0]
+
+			if test.remove {
+				testutil.PinClock(t, removedAt)
+				removed, _, err := Action("remove", "", logId, "", ActionOptions{})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if status := removed.Entries[logId].Status; status != EntryStatusRemoved {
+					t.Errorf("status %s after removing it, want %s", status, EntryStatusRemoved)
+				}
+				if _, _, err := Action("remove", "", logId, "", ActionOptions{}); !errors.Is(err, ErrEntryRemoved) {
+					t.Errorf("removing it again got %v, want %v", err, ErrEntryRemoved)
+				}
+			}
+
+			testutil.PinClock(t, removedAt.Add(test.restoreAfter))
+			_, _, err = Action("restore", "", logId, "", ActionOptions{})
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got %v, want %v", err, test.wantErr)
+			}
+
+			entries, _, err := Action("get", "", logId, "", ActionOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantRemoved := test.wantErr == ErrRestoreWindowPassed
+			if removed := entries.Entries[logId].Status == EntryStatusRemoved; removed != wantRemoved {
+				t.Errorf("status %s after restoring it, want it to be removed: %t", entries.Entries[logId].Status, wantRemoved)
+			}
+		})
+	}
+}
+
+// TestEdit checks that an edit is kept as a revision, so the original message and every earlier edit stay in the history
+func TestEdit(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	at := func(hour int) time.Time {
+		return time.Date(2026, time.October, 17, hour, 0, 0, 0, time.UTC)
+	}
+	action := func(action, message, logId string, hour int) (LogEntry, error) {
+		testutil.PinClock(t, at(hour))
+		entries, logIds, err := Action(action, message, logId, "", ActionOptions{})
+		if err != nil {
+			return LogEntry{}, err
+		}
+		return entries.Entries[logIds[0]], nil
+	}
+
+	added, err := action("add", "first draft #work", "", 9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := action("edit", "second draft @worklog", added.ID, 10); err != nil {
+		t.Fatal(err)
+	}
+	edited, err := action("edit", "final", added.ID, 11)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantRevisions := []Revision{
+		{Message: "first draft #work", Time: at(9).Unix()},
+		{Message: "second draft @worklog", Time: at(10).Unix()},
+		{Message: "final", Time: at(11).Unix()},
+	}
+	if edited.Message != "final" || !reflect.DeepEqual(edited.Revisions, wantRevisions) {
+		t.Errorf("edited %q with history %+v, want %q with %+v", edited.Message, edited.Revisions, "final", wantRevisions)
+	}
+	// The inline tags and project of every edit are kept
+	if !reflect.DeepEqual(edited.Tags, []string{"work"}) || edited.Project != "worklog" {
+		t.Errorf("tags %v and project %q, want [work] and worklog", edited.Tags, edited.Project)
+	}
+
+	var lf LogFile
+	if err := lf.GetLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+	if lf.Log["1017"][1] != "first draft #work" {
+		t.Errorf("the original message was changed to %q", lf.Log["1017"][1])
+	}
+
+	for message, wantErr := range map[string]error{"final": ErrUnchangedMessage, "": ErrEmptyMessage} {
+		if _, err := action("edit", message, added.ID, 12); !errors.Is(err, wantErr) {
+			t.Errorf("editing it to %q got %v, want %v", message, err, wantErr)
+		}
+	}
+
+	// A removed entry can't be edited until it is restored
+	if _, err := action("remove", "", added.ID, 12); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := action("edit", "after removing it", added.ID, 12); !errors.Is(err, ErrEntryRemoved) {
+		t.Errorf("editing a removed entry got %v, want %v", err, ErrEntryRemoved)
+	}
+	restored, err := action("restore", "", added.ID, 13)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(restored.Revisions, wantRevisions) {
+		t.Errorf("history %+v after restoring it, want %+v", restored.Revisions, wantRevisions)
+	}
+}
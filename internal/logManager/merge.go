@@ -0,0 +1,325 @@
+package logManager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to merge two versions of a week file (Used by git as a merge driver when syncing)
+
+// weekEntry holds everything a week file stores about a single log entry
+type weekEntry struct {
+	message   string
+	time      *TimeEntry
+	removed   int64
+	revisions []Revision
+	overrides []Override
+	metadata  *Metadata
+}
+
+// entry returns everything the log file stores about a log entry
+func (l *LogFile) entry(monthDay string, id int) (weekEntry, bool) {
+
+	message, ok := l.Log[monthDay][id]
+	if !ok {
+		return weekEntry{}, false
+	}
+
+	entry := weekEntry{
+		message:   message,
+		removed:   l.Removed[monthDay][id],
+		revisions: l.Revisions[monthDay][id],
+		overrides: l.Overrides[monthDay][id],
+	}
+	if timeEntry, ok := l.Time[monthDay][id]; ok {
+		entry.time = &timeEntry
+	}
+	if metadata, ok := l.Metadata[monthDay][id]; ok {
+		entry.metadata = &metadata
+	}
+
+	return entry, true
+}
+
+// setEntry stores a log entry in the log file
+func (l *LogFile) setEntry(monthDay string, id int, entry weekEntry) {
+
+	if l.Log == nil {
+		l.Log = make(map[string]map[int]string)
+	}
+	if l.Log[monthDay] == nil {
+		l.Log[monthDay] = make(map[int]string)
+	}
+	l.Log[monthDay][id] = entry.message
+
+	if entry.time != nil {
+		if l.Time == nil {
+			l.Time = make(map[string]map[int]TimeEntry)
+		}
+		if l.Time[monthDay] == nil {
+			l.Time[monthDay] = make(map[int]TimeEntry)
+		}
+		l.Time[monthDay][id] = *entry.time
+	}
+
+	if entry.removed != 0 {
+		if l.Removed == nil {
+			l.Removed = make(map[string]map[int]int64)
+		}
+		if l.Removed[monthDay] == nil {
+			l.Removed[monthDay] = make(map[int]int64)
+		}
+		l.Removed[monthDay][id] = entry.removed
+	}
+
+	if len(entry.revisions) > 0 {
+		if l.Revisions == nil {
+			l.Revisions = make(map[string]map[int][]Revision)
+		}
+		if l.Revisions[monthDay] == nil {
+			l.Revisions[monthDay] = make(map[int][]Revision)
+		}
+		l.Revisions[monthDay][id] = entry.revisions
+	}
+
+	if len(entry.overrides) > 0 {
+		if l.Overrides == nil {
+			l.Overrides = make(map[string]map[int][]Override)
+		}
+		if l.Overrides[monthDay] == nil {
+			l.Overrides[monthDay] = make(map[int][]Override)
+		}
+		l.Overrides[monthDay][id] = entry.overrides
+	}
+
+	if entry.metadata != nil {
+		l.setMetadata(monthDay, id, *entry.metadata)
+	}
+}
+
+// mergeValue merges a value which was changed on one or both sides
+// If both sides changed it, both is used to combine them
+func mergeValue[T any](base, ours, theirs T, both func(T, T) T) T {
+	switch {
+	case reflect.DeepEqual(ours, theirs), reflect.DeepEqual(theirs, base):
+		return ours
+	case reflect.DeepEqual(ours, base):
+		return theirs
+	}
+	return both(ours, theirs)
+}
+
+// mergeTimeEntries combines the intervals of both sides (An interval which was ended wins over the running one, and the later end wins)
+func mergeTimeEntries(ours, theirs *TimeEntry) *TimeEntry {
+	if ours == nil {
+		return theirs
+	}
+	if theirs == nil {
+		return ours
+	}
+
+	intervals := make(map[int64]TimeInterval)
+	for _, interval := range append(slices.Clone(ours.Intervals), theirs.Intervals...) {
+		if existing, ok := intervals[interval.Start]; !ok || existing.End == 0 || interval.End > existing.End {
+			intervals[interval.Start] = interval
+		}
+	}
+
+	merged := TimeEntry{End: max(ours.End, theirs.End)}
+	for _, interval := range intervals {
+		merged.Intervals = append(merged.Intervals, interval)
+	}
+	sort.Slice(merged.Intervals, func(i, j int) bool {
+		return merged.Intervals[i].Start < merged.Intervals[j].Start
+	})
+	merged.Total = totalTime(merged.Intervals)
+
+	return &merged
+}
+
+// mergeHistory combines the revisions or overrides of both sides, without duplicates and in the order they happened
+func mergeHistory[T comparable](ours, theirs []T, timeOf func(T) int64) []T {
+	var merged []T
+	for _, item := range append(slices.Clone(ours), theirs...) {
+		if !slices.Contains(merged, item) {
+			merged = append(merged, item)
+		}
+	}
+	sort.SliceStable(merged, func(i, j int) bool {
+		return timeOf(merged[i]) < timeOf(merged[j])
+	})
+	return merged
+}
+
+// mergeEntry merges a log entry which exists on both sides
+func mergeEntry(base, ours, theirs weekEntry) weekEntry {
+
+	return weekEntry{
+		message: mergeValue(base.message, ours.message, theirs.message, func(ours, _ string) string {
+			return ours
+		}),
+		time: mergeValue(base.time, ours.time, theirs.time, mergeTimeEntries),
+		removed: mergeValue(base.removed, ours.removed, theirs.removed, func(ours, theirs int64) int64 {
+			return max(ours, theirs)
+		}),
+		revisions: mergeHistory(ours.revisions, theirs.revisions, func(revision Revision) int64 {
+			return revision.Time
+		}),
+		overrides: mergeHistory(ours.overrides, theirs.overrides, func(override Override) int64 {
+			return override.Time
+		}),
+		metadata: mergeValue(base.metadata, ours.metadata, theirs.metadata, func(ours, theirs *Metadata) *Metadata {
+			if ours == nil {
+				return theirs
+			}
+			if theirs == nil {
+				return ours
+			}
+			merged, err := mergeMetadata(*ours, *theirs)
+			if err != nil {
+				log.Warn("Error merging the tags and project, keeping ours: ", err)
+				return ours
+			}
+			return &merged
+		}),
+	}
+}
+
+// startTime returns the start of the first interval of the entry (0 if it was never started)
+func (e weekEntry) startTime() int64 {
+	if e.time == nil || len(e.time.Intervals) == 0 {
+		return 0
+	}
+	return e.time.Intervals[0].Start
+}
+
+// sameEntry reports whether both sides hold the same entry, rather than two entries which were added with the same ID
+// The original message never changes, so an entry from the base keeps the message of the base on at least one side
+// An entry which isn't in the base was added on both sides, which is only the same entry when it was also started at the same time
+// (Two entries with the same message, I.e a daily "standup", are otherwise different entries)
+func sameEntry(base, ours, theirs weekEntry, inBase bool) bool {
+	if inBase {
+		return ours.message == theirs.message || ours.message == base.message || theirs.message == base.message
+	}
+	return ours.message == theirs.message && ours.startTime() != 0 && ours.startTime() == theirs.startTime()
+}
+
+// MergeLogFiles merges two versions of a week (ours and theirs) which were both changed from the same base
+// Entries are matched by their date and ID, and the changes of both sides are kept
+// An entry which was added on both sides with the same ID is kept on our side, and theirs is given the next free ID
+// It returns the merged week and the IDs which were renumbered (Old ID -> New ID)
+func MergeLogFiles(base, ours, theirs LogFile) (LogFile, map[string]string) {
+
+	var merged LogFile
+	renumbered := make(map[string]string)
+
+	monthDays := make(map[string]bool)
+	for monthDay := range ours.Log {
+		monthDays[monthDay] = true
+	}
+	for monthDay := range theirs.Log {
+		monthDays[monthDay] = true
+	}
+
+	for monthDay := range monthDays {
+
+		var ids []int
+		nextId := 1
+		for _, logFile := range []LogFile{base, ours, theirs} {
+			for id := range logFile.Log[monthDay] {
+				if !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+				nextId = max(nextId, id+1)
+			}
+		}
+		slices.Sort(ids)
+
+		for _, id := range ids {
+
+			baseEntry, inBase := base.entry(monthDay, id)
+			ourEntry, inOurs := ours.entry(monthDay, id)
+			theirEntry, inTheirs := theirs.entry(monthDay, id)
+
+			switch {
+			case inOurs && inTheirs:
+				if sameEntry(baseEntry, ourEntry, theirEntry, inBase) {
+					merged.setEntry(monthDay, id, mergeEntry(baseEntry, ourEntry, theirEntry))
+					continue
+				}
+				merged.setEntry(monthDay, id, ourEntry)
+				merged.setEntry(monthDay, nextId, theirEntry)
+				renumbered[monthDay+"-"+fmt.Sprint(id)] = monthDay + "-" + fmt.Sprint(nextId)
+				nextId++
+			case inOurs:
+				merged.setEntry(monthDay, id, ourEntry)
+			case inTheirs:
+				merged.setEntry(monthDay, id, theirEntry)
+			}
+		}
+	}
+
+	return merged, renumbered
+}
+
+// readWeekFile parses a week file which isn't in the logs path (An empty file is an empty week)
+func readWeekFile(path string) (LogFile, error) {
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return LogFile{}, errors.New("error reading week file (" + path + "): " + err.Error())
+	}
+
+	return parseWeekFile(data, path)
+}
+
+// parseWeekFile parses the contents of a week file (An empty file is an empty week)
+// The source is only used in the error (I.e the path, or the commit the week file is from)
+func parseWeekFile(data []byte, source string) (LogFile, error) {
+
+	var logFile LogFile
+
+	if len(data) == 0 {
+		return logFile, nil
+	}
+
+	if err := json.Unmarshal(data, &logFile); err != nil {
+		return logFile, fmt.Errorf("%w (%s): %v", ErrCorruptLogFile, source, err)
+	}
+
+	return logFile, nil
+}
+
+// MergeWeekFiles merges the week files the way git calls a merge driver (%O %A %B)
+// The merged week is written to the ours file, which is what git uses as the result
+func MergeWeekFiles(basePath, oursPath, theirsPath string) (map[string]string, error) {
+
+	var logFiles [3]LogFile
+	for i, path := range []string{basePath, oursPath, theirsPath} {
+		var err error
+		logFiles[i], err = readWeekFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	merged, renumbered := MergeLogFiles(logFiles[0], logFiles[1], logFiles[2])
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, errors.New("error marshaling merged week file: " + err.Error())
+	}
+
+	if err := writeFileAtomic(oursPath, data); err != nil {
+		return nil, errors.New("error writing merged week file (" + oursPath + "): " + err.Error())
+	}
+
+	return renumbered, nil
+}
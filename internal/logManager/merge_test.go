@@ -0,0 +1,115 @@
+package logManager
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestMergeLogFiles checks how the entries of two versions of a week are matched, merged and renumbered
+func TestMergeLogFiles(t *testing.T) {
+
+	started := func(message string, start int64) weekEntry {
+		return weekEntry{message: message, time: &TimeEntry{Intervals: []TimeInterval{{Start: start}}}}
+	}
+	weekOf := func(entries map[int]weekEntry) LogFile {
+		var logFile LogFile
+		for id, entry := range entries {
+			logFile.setEntry("1017", id, entry)
+		}
+		return logFile
+	}
+
+	standup := started("standup", 100)
+	edited := func(entry weekEntry, revisions ...Revision) weekEntry {
+		entry.revisions = revisions
+		return entry
+	}
+	removed := func(entry weekEntry, at int64) weekEntry {
+		entry.removed = at
+		return entry
+	}
+
+	tests := []struct {
+		name           string
+		base           map[int]weekEntry
+		ours           map[int]weekEntry
+		theirs         map[int]weekEntry
+		want           map[int]weekEntry
+		wantRenumbered map[string]string
+	}{
+		{
+			name:           "both added the same message at different times",
+			ours:           map[int]weekEntry{1: standup},
+			theirs:         map[int]weekEntry{1: started("standup", 200)},
+			want:           map[int]weekEntry{1: standup, 2: started("standup", 200)},
+			wantRenumbered: map[string]string{"1017-1": "1017-2"},
+		},
+		{
+			name:           "both added the same message without a time",
+			ours:           map[int]weekEntry{1: {message: "standup"}},
+			theirs:         map[int]weekEntry{1: {message: "standup"}},
+			want:           map[int]weekEntry{1: {message: "standup"}, 2: {message: "standup"}},
+			wantRenumbered: map[string]string{"1017-1": "1017-2"},
+		},
+		{
+			name:           "both have the same added entry",
+			ours:           map[int]weekEntry{1: standup},
+			theirs:         map[int]weekEntry{1: standup},
+			want:           map[int]weekEntry{1: standup},
+			wantRenumbered: map[string]string{},
+		},
+		{
+			name:           "both added different messages",
+			ours:           map[int]weekEntry{1: started("from ours", 100)},
+			theirs:         map[int]weekEntry{1: started("from theirs", 100)},
+			want:           map[int]weekEntry{1: started("from ours", 100), 2: started("from theirs", 100)},
+			wantRenumbered: map[string]string{"1017-1": "1017-2"},
+		},
+		{
+			name:           "both edited",
+			base:           map[int]weekEntry{1: standup},
+			ours:           map[int]weekEntry{1: edited(standup, Revision{Message: "ours", Time: 300})},
+			theirs:         map[int]weekEntry{1: edited(standup, Revision{Message: "theirs", Time: 200})},
+			want:           map[int]weekEntry{1: edited(standup, Revision{Message: "theirs", Time: 200}, Revision{Message: "ours", Time: 300})},
+			wantRenumbered: map[string]string{},
+		},
+		{
+			name:           "one side removed",
+			base:           map[int]weekEntry{1: standup},
+			ours:           map[int]weekEntry{1: standup},
+			theirs:         map[int]weekEntry{1: removed(standup, 300)},
+			want:           map[int]weekEntry{1: removed(standup, 300)},
+			wantRenumbered: map[string]string{},
+		},
+		{
+			name:           "one side deleted",
+			base:           map[int]weekEntry{1: standup},
+			ours:           map[int]weekEntry{1: standup},
+			theirs:         map[int]weekEntry{},
+			want:           map[int]weekEntry{1: standup},
+			wantRenumbered: map[string]string{},
+		},
+		{
+			name:           "renumbered after the last ID of either side",
+			base:           map[int]weekEntry{1: standup},
+			ours:           map[int]weekEntry{1: standup, 2: started("review", 200)},
+			theirs:         map[int]weekEntry{1: standup, 2: started("deploy", 200), 3: started("retro", 300)},
+			want:           map[int]weekEntry{1: standup, 2: started("review", 200), 3: started("retro", 300), 4: started("deploy", 200)},
+			wantRenumbered: map[string]string{"1017-2": "1017-4"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			merged, renumbered := MergeLogFiles(weekOf(test.base), weekOf(test.ours), weekOf(test.theirs))
+
+			if want := weekOf(test.want); !reflect.DeepEqual(merged, want) {
+				t.Errorf("merged %+v, want %+v", merged, want)
+			}
+			if !reflect.DeepEqual(renumbered, test.wantRenumbered) {
+				t.Errorf("renumbered %v, want %v", renumbered, test.wantRenumbered)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package logManager
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// This file is used to parse the tags and project of the log entries
+
+var (
+	// inlineTagPattern matches the #tag tokens in a message (Tags must start with a letter so that I.e "PR #1337" isn't a tag)
+	// A token can follow an opening bracket or quote (I.e "(#infra)"), and punctuation after it isn't part of it
+	inlineTagPattern = regexp.MustCompile(`(?:^|[\s(\["'])#([A-Za-z][\w-]*)`)
+
+	// inlineProjectPattern matches the @project tokens in a message (The token must start a word so that emails aren't projects)
+	inlineProjectPattern = regexp.MustCompile(`(?:^|[\s(\["'])@([A-Za-z][\w-]*)`)
+
+	// metadataNamePattern is what a tag or project from a flag must look like
+	metadataNamePattern = regexp.MustCompile(`^[\w-]+$`)
+)
+
+// normalizeMetadataName lower cases a tag or project and removes its #/@ prefix
+func normalizeMetadataName(name, prefix string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), prefix))
+	if !metadataNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w (%s), only letters, numbers, - and _ are allowed", ErrInvalidMetadata, prefix+name)
+	}
+	return name, nil
+}
+
+// parseMetadata returns the metadata from the #tag and @project tokens in a message
+func parseMetadata(message string) Metadata {
+
+	var metadata Metadata
+
+	for _, match := range inlineTagPattern.FindAllStringSubmatch(message, -1) {
+		metadata.Tags = append(metadata.Tags, strings.ToLower(match[1]))
+	}
+
+	// An entry belongs to a single project, so the first one wins
+	if match := inlineProjectPattern.FindStringSubmatch(message); match != nil {
+		metadata.Project = strings.ToLower(match[1])
+	}
+
+	return metadata
+}
+
+// mergeMetadata adds the tags of the other metadata and takes its project if it has one
+// Tags and projects are normalized, and the tags are sorted without duplicates
+func mergeMetadata(metadata, other Metadata) (Metadata, error) {
+
+	var merged Metadata
+
+	for _, tag := range append(slices.Clone(metadata.Tags), other.Tags...) {
+		tag, err := normalizeMetadataName(tag, "#")
+		if err != nil {
+			return Metadata{}, err
+		}
+		merged.Tags = append(merged.Tags, tag)
+	}
+	slices.Sort(merged.Tags)
+	merged.Tags = slices.Compact(merged.Tags)
+
+	project := metadata.Project
+	if other.Project != "" {
+		project = other.Project
+	}
+	if project != "" {
+		var err error
+		merged.Project, err = normalizeMetadataName(project, "@")
+		if err != nil {
+			return Metadata{}, err
+		}
+	}
+
+	return merged, nil
+}
+
+// setMetadata sets the metadata of a log entry (Empty metadata isn't stored)
+func (l *LogFile) setMetadata(monthDay string, id int, metadata Metadata) {
+	if len(metadata.Tags) == 0 && metadata.Project == "" {
+		delete(l.Metadata[monthDay], id)
+		return
+	}
+	if l.Metadata == nil {
+		l.Metadata = make(map[string]map[int]Metadata)
+	}
+	if l.Metadata[monthDay] == nil {
+		l.Metadata[monthDay] = make(map[int]Metadata)
+	}
+	l.Metadata[monthDay][id] = metadata
+}
+
+// HasMetadata checks if a log entry has all of the tags and the project (Matching is case-insensitive)
+func (e LogEntry) HasMetadata(tags []string, project string) bool {
+	if project != "" && !strings.EqualFold(strings.TrimPrefix(project, "@"), e.Project) {
+		return false
+	}
+	for _, tag := range tags {
+		if !slices.Contains(e.Tags, strings.ToLower(strings.TrimPrefix(tag, "#"))) {
+			return false
+		}
+	}
+	return true
+}
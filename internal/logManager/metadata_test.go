@@ -0,0 +1,110 @@
+package logManager
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseMetadata(t *testing.T) {
+
+	tests := []struct {
+		message string
+		want    Metadata
+	}{
+		{"no metadata", Metadata{}},
+		{"#Infra deploy @Billing", Metadata{Tags: []string{"infra"}, Project: "billing"}},
+		// Punctuation after a token isn't part of it, and a token can follow an opening bracket or quote
+		{"Deployed #infra, #on-call. (#review) \"#quoted\" @billing!", Metadata{Tags: []string{"infra", "on-call", "review", "quoted"}, Project: "billing"}},
+		// Numbers, anchors and tokens inside a word aren't tags
+		{"Merged PR #1337 and issue#12, see docs#setup", Metadata{}},
+		// Emails aren't projects, and the first project wins
+		{"Emailed ops@example.com about @billing and @payroll", Metadata{Project: "billing"}},
+		// Duplicates are only removed when the metadata is merged
+		{"#infra and #INFRA", Metadata{Tags: []string{"infra", "infra"}}},
+	}
+
+	for _, test := range tests {
+		if got := parseMetadata(test.message); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseMetadata(%q) = %+v, want %+v", test.message, got, test.want)
+		}
+	}
+}
+
+func TestMergeMetadata(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		metadata Metadata
+		other    Metadata
+		want     Metadata
+		wantErr  error
+	}{
+		{
+			name:     "tags are combined, sorted and without duplicates",
+			metadata: Metadata{Tags: []string{"oncall", "infra"}},
+			other:    Metadata{Tags: []string{"#Infra", " review "}},
+			want:     Metadata{Tags: []string{"infra", "oncall", "review"}},
+		},
+		{
+			name:     "the other project wins",
+			metadata: Metadata{Project: "billing"},
+			other:    Metadata{Project: "@Payroll"},
+			want:     Metadata{Project: "payroll"},
+		},
+		{
+			name:     "the project is kept without another one",
+			metadata: Metadata{Project: "billing"},
+			want:     Metadata{Project: "billing"},
+		},
+		{
+			name:    "invalid tag",
+			other:   Metadata{Tags: []string{"on call"}},
+			wantErr: ErrInvalidMetadata,
+		},
+		{
+			name:    "invalid project",
+			other:   Metadata{Project: "billing/2026"},
+			wantErr: ErrInvalidMetadata,
+		},
+	}
+
+	for _, test := range tests {
+		got, err := mergeMetadata(test.metadata, test.other)
+		if !errors.Is(err, test.wantErr) {
+			t.Errorf("%s: got %v, want %v", test.name, err, test.wantErr)
+			continue
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: merged %+v, want %+v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestHasMetadata(t *testing.T) {
+
+	entry := LogEntry{Tags: []string{"infra", "oncall"}, Project: "billing"}
+
+	tests := []struct {
+		tags    []string
+		project string
+		want    bool
+	}{
+		{nil, "", true},
+		{[]string{"infra"}, "", true},
+		{[]string{"#INFRA", "oncall"}, "@Billing", true},
+		{[]string{"infra", "review"}, "", false},
+		{nil, "payroll", false},
+		{[]string{"infra"}, "payroll", false},
+	}
+
+	for _, test := range tests {
+		if got := entry.HasMetadata(test.tags, test.project); got != test.want {
+			t.Errorf("HasMetadata(%v, %q) = %t, want %t", test.tags, test.project, got, test.want)
+		}
+	}
+
+	if (LogEntry{}).HasMetadata([]string{"infra"}, "") {
+		t.Errorf("an entry without tags has the tag infra")
+	}
+}
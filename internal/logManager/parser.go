@@ -3,27 +3,41 @@ package logManager
 import (
 	"encoding/json"
 	"errors"
-	"time"
+	"fmt"
+	"os"
+	"path/filepath"
 
-	"github.com/mitchs-dev/library-go/customTime"
 	"github.com/mitchs-dev/library-go/processor"
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/configuration"
 	log "github.com/sirupsen/logrus"
 )
 
 // This file is used to parse the log files
 
 // GetLogFile opens the log file and returns the contents
+// A log file that doesn't exist is an empty week, and it is only created once an entry is saved to it
+// Files left behind by an interrupted save are only recovered while the logs are locked (See: recoverLogs),
+// so until then a missing or corrupt log file is read from the leftover it will be recovered from
 func (l *LogFile) GetLogFile(logFilePath string) error {
 
+	if leftover := leftoverLogFile(logFilePath); leftover != "" {
+		log.Warn("Reading log file (", logFilePath, ") from ", leftover, ", it is recovered the next time the logs are changed")
+		logFilePath = leftover
+	}
+
 	// Check if the log file exists
-	CreateWeekIfNotExist(logFilePath)
+	if !processor.DirectoryOrFileExists(logFilePath) {
+		log.Debug("Log file does not exist, using an empty week: " + logFilePath)
+		return nil
+	}
 
 	log.Debug("Opening log file: " + logFilePath)
 
 	// Open the log file
 	logFileData := processor.ReadFile(logFilePath)
 	if len(logFileData) == 0 || logFileData == nil {
-		return errors.New("log file (" + logFilePath + ") is empty")
+		return fmt.Errorf("%w (%s): file is empty", ErrCorruptLogFile, logFilePath)
 	}
 
 	log.Debug("Parsing log file: " + logFilePath)
@@ -31,7 +45,7 @@ func (l *LogFile) GetLogFile(logFilePath string) error {
 	// Parse the log file
 	err := json.Unmarshal(logFileData, &l)
 	if err != nil {
-		return errors.New("error parsing log file (" + logFilePath + "): " + err.Error())
+		return fmt.Errorf("%w (%s): %v", ErrCorruptLogFile, logFilePath, err)
 	}
 
 	log.Debug("Log file parsed: " + logFilePath)
@@ -39,22 +53,78 @@ func (l *LogFile) GetLogFile(logFilePath string) error {
 	return nil
 }
 
-// ConvertTime converts epoch time to human readable time (I.e 1hr30min, 1day2hr, etc)
+// UnmarshalJSON parses a time entry, upgrading the legacy flat (s/p/r/e/t) structure to intervals
+// The upgraded structure is written the next time the log file is saved
+func (t *TimeEntry) UnmarshalJSON(data []byte) error {
+
+	var raw struct {
+		Intervals []TimeInterval `json:"i"`
+		legacyTimeEntry
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	t.Intervals = raw.Intervals
+	t.End = raw.End
+	t.Total = raw.Total
+
+	// Anything with intervals (or without a start) is already in the current structure
+	if len(t.Intervals) > 0 || raw.Start == 0 {
+		return nil
+	}
+
+	log.Debug("Upgrading legacy time entry")
+
+	t.Intervals = []TimeInterval{{Start: raw.Start}}
+	if raw.Pause != 0 {
+		t.Intervals[0].End = raw.Pause
+		if raw.Resume > raw.Pause {
+			t.Intervals = append(t.Intervals, TimeInterval{Start: raw.Resume})
+		}
+	}
+	if raw.End != 0 && t.Intervals[len(t.Intervals)-1].End == 0 {
+		t.Intervals[len(t.Intervals)-1].End = raw.End
+	}
+	t.Total = totalTime(t.Intervals)
+
+	return nil
+}
+
+// ConvertTime converts epoch time to the wall-clock time in the timezone of the clock (I.e 14:32)
+// The format is set with settings.output.timeFormat
 func ConvertTime(epochTime int64) string {
-	// Format epoch time to string time
-	strTime := time.Unix(epochTime, 0).String()
+	if epochTime == 0 {
+		return ""
+	}
 
-	// Parse the time
-	parsedTime, err := customTime.ParseDuration(strTime)
-	if err != nil {
-		log.Fatal("Error parsing time: ", err)
+	timeFormat := configuration.OutputTimeFormat
+	if timeFormat == "" {
+		timeFormat = "15:04"
 	}
 
-	// Format the time to string
-	parsedTimeStr := parsedTime.String()
+	return calendarManager.FromEpoch(epochTime).Format(timeFormat)
+}
 
-	return parsedTimeStr
+// ConvertDuration converts seconds to a human readable duration (I.e 1h25m, 1:25 or 1.42h)
+// The format is set with settings.output.durationFormat
+func ConvertDuration(seconds int64) string {
+	if seconds < 0 {
+		seconds = 0
+	}
 
+	switch configuration.OutputDurationFormat {
+	case "clock":
+		return fmt.Sprintf("%d:%02d", seconds/3600, seconds%3600/60)
+	case "decimal":
+		return fmt.Sprintf("%.2fh", float64(seconds)/3600)
+	default:
+		hours, minutes := seconds/3600, seconds%3600/60
+		if hours == 0 {
+			return fmt.Sprintf("%dm", minutes)
+		}
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
 }
 
 // SaveLogFile saves the log file
@@ -68,35 +138,21 @@ func (l *LogFile) SaveLogFile(logFilePath string) error {
 		return errors.New("error marshaling log file (" + logFilePath + "): " + err.Error())
 	}
 
-	// Backup the log file
-	if !processor.CopyFile(logFilePath, logFilePath+".bak") {
-		return errors.New("error backing up log file (" + logFilePath + ")")
-	}
-
-	// Delete the log file
-	if !processor.FileDelete(logFilePath) {
-		return errors.New("error deleting log file (" + logFilePath + ")")
-	}
-
-	// Save the log file
-	if !processor.CreateFileAsByte(logFilePath, logFileData) {
-		// Restore the log file
-		if !processor.CopyFile(logFilePath+".bak", logFilePath) {
-			return errors.New("error restoring log file (" + logFilePath + ")")
-		}
-		// Delete the backup log file
-		if !processor.FileDelete(logFilePath + ".bak") {
-			return errors.New("error deleting backup log file (" + logFilePath + ".bak)")
-		}
-		return errors.New("error saving log file (" + logFilePath + ")")
+	// Create the year directory if this is the first week of the year
+	err = os.MkdirAll(filepath.Dir(logFilePath), 0755)
+	if err != nil {
+		return errors.New("error creating log file directory (" + filepath.Dir(logFilePath) + "): " + err.Error())
 	}
 
-	// Delete the backup log file
-	if !processor.FileDelete(logFilePath + ".bak") {
-		return errors.New("error deleting backup log file (" + logFilePath + ".bak)")
+	// Write the log file atomically so that a crash can't leave it missing or partially written
+	err = writeFileAtomic(logFilePath, logFileData)
+	if err != nil {
+		return errors.New("error saving log file (" + logFilePath + "): " + err.Error())
 	}
 
 	log.Debug("Log file saved: " + logFilePath)
 
+	updateIndex(logFilePath, *l)
+
 	return nil
 }
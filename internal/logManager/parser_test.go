@@ -0,0 +1,56 @@
+package logManager
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestLegacyTimeEntries checks that the legacy flat (s/p/r/e/t) time entries are upgraded to intervals, and that they are saved in the current structure
+func TestLegacyTimeEntries(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	want := map[int]TimeEntry{
+		1: {Intervals: []TimeInterval{{Start: 1000}}},
+		2: {Intervals: []TimeInterval{{Start: 1000, End: 1600}}, Total: 600},
+		3: {Intervals: []TimeInterval{{Start: 1000, End: 1600}, {Start: 2000}}, Total: 600},
+		4: {Intervals: []TimeInterval{{Start: 1000, End: 1600}, {Start: 2000, End: 2900}}, End: 2900, Total: 1500},
+		5: {Intervals: []TimeInterval{{Start: 1000, End: 4600}}, End: 4600, Total: 3600},
+		6: {Intervals: []TimeInterval{{Start: 1000, End: 1600}}, End: 2000, Total: 600},
+		7: {},
+	}
+
+	var legacy LogFile
+	if err := legacy.GetLogFile("testdata/legacy-week.json"); err != nil {
+		t.Fatal(err)
+	}
+	for id, wantEntry := range want {
+		if got := legacy.Time["1017"][id]; !reflect.DeepEqual(got, wantEntry) {
+			t.Errorf("entry %d (%s) = %+v, want %+v", id, legacy.Log["1017"][id], got, wantEntry)
+		}
+	}
+
+	// Saving writes the intervals, which load the same again
+	if err := legacy.SaveLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(weekFilePath("2026/42"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(data, []byte(`"p":`)) || bytes.Contains(data, []byte(`"r":`)) {
+		t.Errorf("the saved week file still has legacy time entries: %s", data)
+	}
+
+	var saved LogFile
+	if err := saved.GetLogFile(weekFilePath("2026/42")); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(saved, legacy) {
+		t.Errorf("loaded %+v after saving, want %+v", saved, legacy)
+	}
+}
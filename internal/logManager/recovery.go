@@ -0,0 +1,179 @@
+package logManager
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/mitchs-dev/library-go/processor"
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to save the log files atomically and recover any files left behind by an interrupted save
+
+const (
+	// backupSuffix is the suffix of the backups made by older versions of SaveLogFile
+	backupSuffix = ".bak"
+	// tempPattern is the pattern of the temporary files written by SaveLogFile
+	tempPattern = ".*.tmp"
+)
+
+// writeFileAtomic writes the data to a temporary file in the same directory, syncs it and then renames it over the file
+// The file is either the old contents or the new contents, even if the process dies part way through
+func writeFileAtomic(filePath string, data []byte) error {
+
+	tmpFile, err := os.CreateTemp(filepath.Dir(filePath), filepath.Base(filePath)+tempPattern)
+	if err != nil {
+		return err
+	}
+	tmpFilePath := tmpFile.Name()
+
+	// CreateTemp creates the file with 0600, so keep the mode of the file it replaces
+	fileMode := os.FileMode(0644)
+	if fileInfo, err := os.Stat(filePath); err == nil {
+		fileMode = fileInfo.Mode().Perm()
+	}
+	if err := tmpFile.Chmod(fileMode); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFilePath)
+		return err
+	}
+
+	if _, err := tmpFile.Write(data); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFilePath)
+		return err
+	}
+	if err := tmpFile.Sync(); err != nil {
+		tmpFile.Close()
+		os.Remove(tmpFilePath)
+		return err
+	}
+	if err := tmpFile.Close(); err != nil {
+		os.Remove(tmpFilePath)
+		return err
+	}
+
+	if err := os.Rename(tmpFilePath, filePath); err != nil {
+		os.Remove(tmpFilePath)
+		return err
+	}
+
+	// Sync the directory so that the rename is persisted
+	if dir, err := os.Open(filepath.Dir(filePath)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+
+	return nil
+}
+
+// validLogFile checks if the file exists and holds a log file
+func validLogFile(filePath string) bool {
+	data, err := os.ReadFile(filePath)
+	if err != nil || len(data) == 0 {
+		return false
+	}
+	var lf LogFile
+	return json.Unmarshal(data, &lf) == nil
+}
+
+// leftoverFiles returns the backup and temporary files of the log file, in the order they are recovered from
+func leftoverFiles(logFilePath string) ([]string, error) {
+
+	tmpFilePaths, err := filepath.Glob(logFilePath + tempPattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// Temporary files are newer than the backup, so they are tried first
+	leftovers := tmpFilePaths
+	if processor.DirectoryOrFileExists(logFilePath + backupSuffix) {
+		leftovers = append(leftovers, logFilePath+backupSuffix)
+	}
+
+	return leftovers, nil
+}
+
+// leftoverLogFile returns the leftover the log file would be recovered from, or an empty string if it doesn't need to be recovered
+// It only reads the files, so it can be used without the lock (The leftover is recovered the next time the logs are locked)
+func leftoverLogFile(logFilePath string) string {
+
+	leftovers, err := leftoverFiles(logFilePath)
+	if err != nil || len(leftovers) == 0 || validLogFile(logFilePath) {
+		return ""
+	}
+
+	for _, leftover := range leftovers {
+		if validLogFile(leftover) {
+			return leftover
+		}
+	}
+
+	return ""
+}
+
+// recoverLogFile recovers the log file from any backup or temporary files left behind by an interrupted save
+// The log file is kept if it is valid, otherwise the newest valid leftover replaces it
+func recoverLogFile(logFilePath string) error {
+
+	leftovers, err := leftoverFiles(logFilePath)
+	if err != nil {
+		return err
+	}
+
+	if len(leftovers) == 0 {
+		return nil
+	}
+
+	logFileValid := validLogFile(logFilePath)
+
+	for _, leftover := range leftovers {
+		if !logFileValid && validLogFile(leftover) {
+			log.Warn("Recovering log file (", logFilePath, ") from ", leftover)
+			if err := os.Rename(leftover, logFilePath); err != nil {
+				return errors.New("error recovering log file (" + logFilePath + ") from " + leftover + ": " + err.Error())
+			}
+			logFileValid = true
+			continue
+		}
+
+		log.Debug("Removing leftover file: ", leftover)
+		if err := os.Remove(leftover); err != nil {
+			return errors.New("error removing leftover file (" + leftover + "): " + err.Error())
+		}
+	}
+
+	return nil
+}
+
+// recoverLogs recovers every week file which has files left behind by an interrupted save
+// It must only be called while the logs are locked, since the temporary file of a save in progress looks the same as a leftover
+func recoverLogs() error {
+
+	var logFilePaths []string
+	for _, pattern := range []string{tempPattern, backupSuffix} {
+		leftovers, err := filepath.Glob(filepath.Join(configuration.LogsPath, "[0-9][0-9][0-9][0-9]", "[0-9][0-9]"+pattern))
+		if err != nil {
+			return err
+		}
+		for _, leftover := range leftovers {
+			// Strip the suffix to get the week file (I.e 2026/07.123.tmp -> 2026/07)
+			logFilePath := filepath.Join(filepath.Dir(leftover), filepath.Base(leftover)[:2])
+			if !slices.Contains(logFilePaths, logFilePath) {
+				logFilePaths = append(logFilePaths, logFilePath)
+			}
+		}
+	}
+
+	for _, logFilePath := range logFilePaths {
+		if err := recoverLogFile(logFilePath); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package logManager
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+const (
+	validWeek   = `{"Log":{"1017":{"1":"entry"}}}`
+	partialWeek = `{"Log":{"1017":{"1":"ent`
+)
+
+// writeTestFile writes a file for a test
+func writeTestFile(t *testing.T, filePath, data string, fileMode os.FileMode) {
+	t.Helper()
+	if err := os.WriteFile(filePath, []byte(data), fileMode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// leftovers returns the backup and temporary files next to the log file
+func leftovers(t *testing.T, logFilePath string) []string {
+	t.Helper()
+	found, err := leftoverFiles(logFilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return found
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+
+	filePath := filepath.Join(t.TempDir(), "42")
+
+	if err := writeFileAtomic(filePath, []byte(validWeek)); err != nil {
+		t.Fatal(err)
+	}
+	if fileInfo, err := os.Stat(filePath); err != nil || fileInfo.Mode().Perm() != 0644 {
+		t.Errorf("created %v (%v), want mode 0644", fileInfo, err)
+	}
+
+	// Replacing a file keeps its mode
+	if err := os.Chmod(filePath, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := writeFileAtomic(filePath, []byte(`{"Log":{}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if data, err := os.ReadFile(filePath); err != nil || string(data) != `{"Log":{}}` {
+		t.Errorf("read %q (%v), want the new contents", data, err)
+	}
+	if fileInfo, err := os.Stat(filePath); err != nil || fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("replaced %v (%v), want mode 0600", fileInfo, err)
+	}
+
+	if found := leftovers(t, filePath); len(found) != 0 {
+		t.Errorf("left behind %v", found)
+	}
+}
+
+func TestRecoverLogFile(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		week     string // The contents of the week file ("" if it is missing)
+		tmp      string // The contents of a temporary file ("" if there is none)
+		bak      string // The contents of the backup ("" if there is none)
+		wantWeek string // The contents of the week file after recovering ("" if it is missing)
+	}{
+		{
+			name:     "a partial temporary file next to a valid week",
+			week:     validWeek,
+			tmp:      partialWeek,
+			wantWeek: validWeek,
+		},
+		{
+			name:     "a backup with the week file missing",
+			bak:      validWeek,
+			wantWeek: validWeek,
+		},
+		{
+			name:     "a temporary file and a backup with the week file missing",
+			tmp:      validWeek,
+			bak:      `{"Log":{}}`,
+			wantWeek: validWeek,
+		},
+		{
+			name:     "a partial temporary file before a valid backup",
+			week:     partialWeek,
+			tmp:      partialWeek,
+			bak:      validWeek,
+			wantWeek: validWeek,
+		},
+		{
+			name: "a partial temporary file with the week file missing",
+			tmp:  partialWeek,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			logFilePath := filepath.Join(t.TempDir(), "42")
+			if test.week != "" {
+				writeTestFile(t, logFilePath, test.week, 0644)
+			}
+			if test.tmp != "" {
+				writeTestFile(t, logFilePath+".123.tmp", test.tmp, 0600)
+			}
+			if test.bak != "" {
+				writeTestFile(t, logFilePath+backupSuffix, test.bak, 0644)
+			}
+
+			if err := recoverLogFile(logFilePath); err != nil {
+				t.Fatal(err)
+			}
+
+			data, err := os.ReadFile(logFilePath)
+			switch {
+			case test.wantWeek == "" && !os.IsNotExist(err):
+				t.Errorf("read %q (%v), want the week file to be missing", data, err)
+			case test.wantWeek != "" && string(data) != test.wantWeek:
+				t.Errorf("read %q (%v), want %q", data, err, test.wantWeek)
+			}
+			if found := leftovers(t, logFilePath); len(found) != 0 {
+				t.Errorf("left behind %v", found)
+			}
+		})
+	}
+}
+
+// TestRecoverLogs simulates saves which were interrupted, and checks that the week is read from the leftovers until the logs are locked and recovered
+func TestRecoverLogs(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+	testutil.PinClock(t, time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC))
+
+	if _, _, err := Action("add", "before the crash", "", "", ActionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	logFilePath := weekFilePath("2026/42")
+
+	// An older version was interrupted after it moved the week file to the backup
+	if err := os.Rename(logFilePath, logFilePath+backupSuffix); err != nil {
+		t.Fatal(err)
+	}
+	// And a save was interrupted while writing the temporary file of another week
+	otherWeek := filepath.Join(configuration.LogsPath, "2026", "41")
+	writeTestFile(t, otherWeek+".456.tmp", partialWeek, 0600)
+
+	// Reading doesn't lock the logs, so it reads the backup without recovering it
+	entries, _, err := Action("get", "", "1017-1", "", ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries.Entries["1017-1"].Message != "before the crash" {
+		t.Errorf("got %+v, want the entry from the backup", entries.Entries["1017-1"])
+	}
+	if _, err := os.Stat(logFilePath + backupSuffix); err != nil {
+		t.Errorf("the backup was recovered without the lock: %v", err)
+	}
+
+	unlock, err := LockLogs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	unlock()
+
+	if !validLogFile(logFilePath) {
+		t.Errorf("the week file was not recovered from the backup")
+	}
+	for _, week := range []string{logFilePath, otherWeek} {
+		if found := leftovers(t, week); len(found) != 0 {
+			t.Errorf("left behind %v", found)
+		}
+	}
+	if _, err := os.Stat(otherWeek); !os.IsNotExist(err) {
+		t.Errorf("a week file was created from a partial temporary file")
+	}
+}
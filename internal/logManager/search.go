@@ -0,0 +1,173 @@
+package logManager
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mitchs-dev/library-go/processor"
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to search the entire history of the log entries
+
+var (
+	// SearchModes are the ways a query can be matched against the messages (The first is the default)
+	SearchModes = []string{
+		"words",  // Every word of the query is in the message (Anywhere and in any order)
+		"phrase", // The words of the query are in the message as a phrase
+		"regex",  // The query is a regular expression (Go syntax)
+	}
+)
+
+// SearchQuery holds what to search for
+type SearchQuery struct {
+	Text          string // The query
+	Mode          string // One of SearchModes (Defaults to words)
+	CaseSensitive bool   // Match the case of the query
+	From          string // Only search entries from this date (YYYY-MM-DD)
+	To            string // Only search entries up to this date (YYYY-MM-DD)
+}
+
+// Search searches the messages of every log entry in the logs path (Within the date bounds of the query)
+// The matching log ids are returned ordered by date and then by id
+func Search(query SearchQuery) (LogFileEntries, []string, error) {
+
+	matcher, err := query.matcher()
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	for _, date := range []string{query.From, query.To} {
+		if date == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", date); err != nil {
+			return LogFileEntries{}, nil, fmt.Errorf("%w: invalid date (%s), expected format YYYY-MM-DD", ErrInvalidQuery, date)
+		}
+	}
+	if query.From != "" && query.To != "" && query.To < query.From {
+		return LogFileEntries{}, nil, fmt.Errorf("%w: end date (%s) is before start date (%s)", ErrInvalidQuery, query.To, query.From)
+	}
+
+	weeks, err := searchWeeks(query)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	entries := LogFileEntries{
+		Entries: make(map[string]LogEntry),
+	}
+	var entryIDs []string
+
+	now := calendarManager.NowEpoch()
+
+	for _, week := range weeks {
+		log.Debug("Searching week: ", week)
+		weekEntries, err := readWeekEntries(week)
+		if err != nil {
+			return LogFileEntries{}, nil, err
+		}
+		for _, indexEntry := range weekEntries {
+			if !matcher(indexEntry.message()) {
+				continue
+			}
+			logEntry := indexEntry.logEntry(week, now)
+			if (query.From != "" && logEntry.Date < query.From) || (query.To != "" && logEntry.Date > query.To) {
+				continue
+			}
+			entryIDs = append(entryIDs, entries.addEntry(logEntry))
+		}
+	}
+
+	entryIDs, err = SortLogIds(entries, entryIDs, "", false)
+	if err != nil {
+		return LogFileEntries{}, nil, err
+	}
+
+	return entries, entryIDs, nil
+}
+
+// searchWeeks returns the weeks (YYYY/WW) to search
+// With a start date only the weeks in the date bounds are searched, otherwise every week file is
+func searchWeeks(query SearchQuery) ([]string, error) {
+
+	if query.From == "" {
+		return weekFiles()
+	}
+
+	dirs, _, _, _, err := calendarManager.PeriodFetch(calendarManager.DateRangePeriod(query.From, query.To))
+	if err != nil {
+		return nil, err
+	}
+
+	var weeks []string
+	for _, week := range dirs {
+		if processor.DirectoryOrFileExists(weekFilePath(week)) {
+			weeks = append(weeks, week)
+		}
+	}
+
+	return weeks, nil
+}
+
+// matcher returns a function that checks if a message matches the query
+func (q SearchQuery) matcher() (func(string) bool, error) {
+
+	if strings.TrimSpace(q.Text) == "" {
+		return nil, fmt.Errorf("%w: the query is empty", ErrInvalidQuery)
+	}
+
+	mode := strings.ToLower(q.Mode)
+	if mode == "" {
+		mode = SearchModes[0]
+	}
+	if !slices.Contains(SearchModes, mode) {
+		return nil, fmt.Errorf("%w: invalid search mode (%s), expected one of: %s", ErrInvalidQuery, q.Mode, strings.Join(SearchModes, ", "))
+	}
+
+	var pattern string
+	switch mode {
+	case "words":
+		words := strings.Fields(q.Text)
+		if !q.CaseSensitive {
+			for i := range words {
+				words[i] = strings.ToLower(words[i])
+			}
+		}
+		return func(message string) bool {
+			if !q.CaseSensitive {
+				message = strings.ToLower(message)
+			}
+			for _, word := range words {
+				if !strings.Contains(message, word) {
+					return false
+				}
+			}
+			return true
+		}, nil
+	case "phrase":
+		// The phrase has to start and end on a word boundary, and any whitespace between the words matches
+		words := strings.Fields(q.Text)
+		for i := range words {
+			words[i] = regexp.QuoteMeta(words[i])
+		}
+		pattern = `(?:^|\W)` + strings.Join(words, `\s+`) + `(?:$|\W)`
+	case "regex":
+		pattern = q.Text
+	}
+
+	if !q.CaseSensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid regular expression (%s): %v", ErrInvalidQuery, q.Text, err)
+	}
+
+	return compiled.MatchString, nil
+}
@@ -0,0 +1,74 @@
+package logManager
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+func TestSearch(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	for _, entry := range []struct {
+		month   time.Month
+		day     int
+		message string
+	}{
+		{time.January, 5, "Deployed the invoice export"},
+		{time.March, 10, "Code review of the billing service"},
+		{time.March, 10, "Reviewed PR #1337"},
+		{time.March, 10, "invoicing fixes"},
+		{time.June, 1, "CODE  review\nfollow-up"},
+	} {
+		testutil.PinClock(t, time.Date(2026, entry.month, entry.day, 9, 0, 0, 0, time.UTC))
+		if _, _, err := Action("add", entry.message, "", "", ActionOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		query SearchQuery
+		want  []string
+	}{
+		{"words in any order", SearchQuery{Text: "export invoice"}, []string{"0105-1"}},
+		{"words match inside words", SearchQuery{Text: "view COD"}, []string{"0310-1", "0601-1"}},
+		{"words match the start of a word", SearchQuery{Text: "invoic"}, []string{"0105-1", "0310-3"}},
+		{"words with the case", SearchQuery{Text: "code", CaseSensitive: true}, nil},
+		{"phrase across whitespace", SearchQuery{Text: "code review", Mode: "phrase"}, []string{"0310-1", "0601-1"}},
+		{"phrase on word boundaries", SearchQuery{Text: "code rev", Mode: "phrase"}, nil},
+		{"regex", SearchQuery{Text: `PR #\d+`, Mode: "regex"}, []string{"0310-2"}},
+		{"regex with the case", SearchQuery{Text: `^CODE`, Mode: "REGEX", CaseSensitive: true}, []string{"0601-1"}},
+		{"from and to", SearchQuery{Text: "review", From: "2026-03-10", To: "2026-03-31"}, []string{"0310-1", "0310-2"}},
+		{"from", SearchQuery{Text: "review", From: "2026-03-11"}, []string{"0601-1"}},
+		{"to", SearchQuery{Text: "review", To: "2026-03-10"}, []string{"0310-1", "0310-2"}},
+		{"on a single day", SearchQuery{Text: "e", From: "2026-01-05", To: "2026-01-05"}, []string{"0105-1"}},
+	}
+
+	for _, test := range tests {
+		_, logIds, err := Search(test.query)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(logIds, test.want) {
+			t.Errorf("%s: found %v, want %v", test.name, logIds, test.want)
+		}
+	}
+
+	for _, query := range []SearchQuery{
+		{Text: "  "},
+		{Text: "review", Mode: "fuzzy"},
+		{Text: "(", Mode: "regex"},
+		{Text: "review", From: "2026-3-10"},
+		{Text: "review", From: "2026-03-10", To: "2026-03-01"},
+	} {
+		if _, _, err := Search(query); !errors.Is(err, ErrInvalidQuery) {
+			t.Errorf("searching %+v got %v, want %v", query, err, ErrInvalidQuery)
+		}
+	}
+}
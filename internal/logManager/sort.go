@@ -0,0 +1,68 @@
+package logManager
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// This file is used to sort the log entries
+
+var (
+	// SortOrders are the orders that the log entries can be sorted in (The first is the default)
+	SortOrders = []string{
+		"id",       // By date and then by id (The order they were added)
+		"time",     // By date and then by start time
+		"duration", // By the time worked (Shortest first)
+	}
+)
+
+// SortLogIds sorts the log ids of the entries by the sort order
+// Ties are broken by the date and then the id, so the order is the same on every run
+func SortLogIds(entries LogFileEntries, logIds []string, sortOrder string, reverse bool) ([]string, error) {
+
+	sortOrder = strings.ToLower(sortOrder)
+	if sortOrder == "" {
+		sortOrder = SortOrders[0]
+	}
+	if !slices.Contains(SortOrders, sortOrder) {
+		return nil, errors.New("invalid sort order (" + sortOrder + "), expected one of: " + strings.Join(SortOrders, ", "))
+	}
+
+	sortedLogIds := slices.Clone(logIds)
+	slices.SortFunc(sortedLogIds, func(a, b string) int {
+		compared := compareLogEntries(entries.Entries[a], entries.Entries[b], sortOrder)
+		if reverse {
+			return -compared
+		}
+		return compared
+	})
+
+	return sortedLogIds, nil
+}
+
+// compareLogEntries compares two log entries by the sort order
+func compareLogEntries(a, b LogEntry, sortOrder string) int {
+	if sortOrder == "duration" {
+		if compared := cmp.Compare(a.Elapsed, b.Elapsed); compared != 0 {
+			return compared
+		}
+	}
+	if compared := cmp.Compare(a.Date, b.Date); compared != 0 {
+		return compared
+	}
+	if sortOrder == "time" {
+		if compared := cmp.Compare(a.Started, b.Started); compared != 0 {
+			return compared
+		}
+	}
+	return cmp.Compare(logIdNumber(a.ID), logIdNumber(b.ID))
+}
+
+// logIdNumber returns the id for the day of a log id (I.e 4 for 0123-4)
+func logIdNumber(logId string) int {
+	id, _ := strconv.Atoi(logId[strings.LastIndex(logId, "-")+1:])
+	return id
+}
@@ -0,0 +1,104 @@
+package logManager
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+func TestSortLogIds(t *testing.T) {
+
+	at := func(day, hour int) int64 {
+		return time.Date(2026, time.October, day, hour, 0, 0, 0, time.UTC).Unix()
+	}
+
+	entries := LogFileEntries{Entries: map[string]LogEntry{
+		"1016-2":  {ID: "1016-2", Date: "2026-10-16", Started: at(16, 10), Elapsed: 3600},
+		"1016-10": {ID: "1016-10", Date: "2026-10-16", Started: at(16, 9), Elapsed: 600},
+		"1017-1":  {ID: "1017-1", Date: "2026-10-17", Started: at(17, 11), Elapsed: 3600},
+		"1017-2":  {ID: "1017-2", Date: "2026-10-17", Started: at(17, 8), Elapsed: 1800, Status: EntryStatusStarted},
+		"1017-3":  {ID: "1017-3", Date: "2026-10-17", Elapsed: 0, Status: EntryStatusAdded},
+		// The same log id a year earlier is listed under its date
+		"2025-10-17/1017-1": {ID: "1017-1", Date: "2025-10-17", Started: at(17, 12) - 365*24*3600, Elapsed: 1800},
+	}}
+	logIds := []string{"1017-3", "1016-10", "1017-1", "2025-10-17/1017-1", "1016-2", "1017-2"}
+
+	tests := []struct {
+		sortOrder string
+		reverse   bool
+		want      []string
+	}{
+		// Ids are compared as numbers, so 1016-10 is after 1016-2
+		{"", false, []string{"2025-10-17/1017-1", "1016-2", "1016-10", "1017-1", "1017-2", "1017-3"}},
+		{"id", true, []string{"1017-3", "1017-2", "1017-1", "1016-10", "1016-2", "2025-10-17/1017-1"}},
+		// An entry which was never started has no start time, so it is first on its day
+		{"time", false, []string{"2025-10-17/1017-1", "1016-10", "1016-2", "1017-3", "1017-2", "1017-1"}},
+		// Ties are broken by the date and then the id
+		{"duration", false, []string{"1017-3", "1016-10", "2025-10-17/1017-1", "1017-2", "1016-2", "1017-1"}},
+		{"DURATION", true, []string{"1017-1", "1016-2", "1017-2", "2025-10-17/1017-1", "1016-10", "1017-3"}},
+	}
+
+	for _, test := range tests {
+		sorted, err := SortLogIds(entries, logIds, test.sortOrder, test.reverse)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(sorted, test.want) {
+			t.Errorf("sorted by %q (reverse: %t) = %v, want %v", test.sortOrder, test.reverse, sorted, test.want)
+		}
+	}
+
+	if !slices.Equal(logIds, []string{"1017-3", "1016-10", "1017-1", "2025-10-17/1017-1", "1016-2", "1017-2"}) {
+		t.Errorf("the log ids were sorted in place: %v", logIds)
+	}
+
+	if _, err := SortLogIds(entries, logIds, "message", false); err == nil {
+		t.Errorf("got no error for an invalid sort order")
+	}
+}
+
+// TestSortRunningEntries checks that an entry which is still running is sorted by the time worked on it until now
+func TestSortRunningEntries(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	setNow := func(hour, minute int) {
+		testutil.PinClock(t, time.Date(2026, time.October, 17, hour, minute, 0, 0, time.UTC))
+	}
+	action := func(action, message, logId string) {
+		t.Helper()
+		if _, _, err := Action(action, message, logId, "", ActionOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setNow(9, 0)
+	action("add", "ended after an hour", "")
+	setNow(10, 0)
+	action("end", "", "1017-1")
+	action("add", "still running", "")
+
+	for _, test := range []struct {
+		hour, minute int
+		want         []string
+	}{
+		{10, 30, []string{"1017-2", "1017-1"}},
+		{11, 30, []string{"1017-1", "1017-2"}},
+	} {
+		setNow(test.hour, test.minute)
+		entries, logIds, err := Action("list", "", "", "today", ActionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sorted, err := SortLogIds(entries, logIds, "duration", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(sorted, test.want) {
+			t.Errorf("sorted by duration at %02d:%02d = %v, want %v", test.hour, test.minute, sorted, test.want)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package outputManager
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+)
+
+// csvFormatter formats the list output as CSV (I.e for spreadsheets)
+type csvFormatter struct{}
+
+// Format formats the list output as CSV with a header row
+func (csvFormatter) Format(list List, options Options) (string, error) {
+
+	var csvReturn bytes.Buffer
+	csvWriter := csv.NewWriter(&csvReturn)
+
+	records := [][]string{
+		{"id", "date", "week", "time", "status", "elapsed", "elapsed_seconds", "message", "tags", "project"},
+	}
+	for _, entry := range list.Entries {
+		records = append(records, []string{
+			entry.ID,
+			entry.Date,
+			entry.Week,
+			entry.Time,
+			entry.Status,
+			entry.Elapsed,
+			fmt.Sprint(entry.ElapsedSeconds),
+			entry.Message,
+			strings.Join(entry.Tags, " "),
+			entry.Project,
+		})
+	}
+
+	if err := csvWriter.WriteAll(records); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(csvReturn.String()), nil
+}
@@ -0,0 +1,48 @@
+// The outputManager package is responsible for formatting the list output.
+package outputManager
+
+import (
+	"errors"
+	"sort"
+	"strings"
+)
+
+// This file holds the registry of the output formats
+// Adding a new format only requires a Formatter and registering it in formatters
+
+// Formatter formats the list output
+// An empty string means that there was nothing to output
+type Formatter interface {
+	Format(list List, options Options) (string, error)
+}
+
+var (
+	// formatters is a map of the output formats to their formatter
+	formatters = map[string]Formatter{
+		"text":     textFormatter{},
+		"json":     jsonFormatter{},
+		"yaml":     yamlFormatter{},
+		"csv":      csvFormatter{},
+		"markdown": markdownFormatter{},
+		"template": templateFormatter{},
+	}
+)
+
+// Formats returns the names of the output formats
+func Formats() []string {
+	var formats []string
+	for format := range formatters {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// Format formats the list output with the output format
+func Format(format string, list List, options Options) (string, error) {
+	formatter, ok := formatters[strings.ToLower(format)]
+	if !ok {
+		return "", errors.New("invalid output format (" + format + "), expected one of: " + strings.Join(Formats(), ", "))
+	}
+	return formatter.Format(list, options)
+}
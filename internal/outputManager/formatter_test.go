@@ -0,0 +1,206 @@
+package outputManager
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// update rewrites the golden files with the current output (go test ./internal/outputManager -update)
+var update = flag.Bool("update", false, "update the golden files")
+
+// testList returns a list with entries on two days, including the characters which CSV and Markdown have to escape
+func testList() List {
+
+	at := func(day, hour, minute int) *time.Time {
+		at := time.Date(2026, time.October, day, hour, minute, 0, 0, time.UTC)
+		return &at
+	}
+
+	return List{
+		Period: "2026-10-16..2026-10-17",
+		Entries: []Entry{
+			{
+				ID:             "1016-1",
+				Date:           "2026-10-16",
+				Week:           "2026-W42",
+				Status:         "completed",
+				Time:           "09:00",
+				Started:        at(16, 9, 0),
+				Elapsed:        "1h25m",
+				ElapsedSeconds: 5100,
+				Message:        `Reviewed "billing", invoices & exports`,
+				Tags:           []string{"review", "billing"},
+				Project:        "worklog",
+			},
+			{
+				ID:             "1016-2",
+				Date:           "2026-10-16",
+				Week:           "2026-W42",
+				Status:         "removed",
+				Time:           "11:30",
+				Started:        at(16, 11, 30),
+				Elapsed:        "0m",
+				ElapsedSeconds: 0,
+				Message:        "Fixed *bold* _italic_ `code` [link](url) <tag> a|b \\ #infra",
+				Tags:           []string{"infra"},
+			},
+			{
+				ID:             "1017-1",
+				Date:           "2026-10-17",
+				Week:           "2026-W42",
+				Status:         "started",
+				Time:           "08:15",
+				Started:        at(17, 8, 15),
+				Elapsed:        "45m",
+				ElapsedSeconds: 2700,
+				Message:        "Optimized database queries,\nsecond line",
+				History: []Revision{
+					{Time: *at(17, 8, 15), Message: "Optimised database queries"},
+					{Time: *at(17, 8, 20), Message: "Optimized database queries,\nsecond line"},
+				},
+			},
+		},
+	}
+}
+
+// TestFormat compares the output of every format with its golden file (testdata/list.<format>)
+func TestFormat(t *testing.T) {
+
+	options := Options{TemplateFile: filepath.Join("testdata", "summary.tmpl")}
+
+	for _, format := range Formats() {
+		t.Run(format, func(t *testing.T) {
+
+			output, err := Format(format, testList(), options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			goldenPath := filepath.Join("testdata", "list."+format)
+			if *update {
+				if err := os.WriteFile(goldenPath, []byte(output+"\n"), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			golden, err := os.ReadFile(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if output+"\n" != string(golden) {
+				t.Errorf("output of %s doesn't match %s:\n%s", format, goldenPath, output)
+			}
+		})
+	}
+}
+
+func TestFormatEmptyList(t *testing.T) {
+
+	for _, format := range []string{"text", "markdown"} {
+		if output, err := Format(format, List{Period: "today"}, Options{}); err != nil || output != "" {
+			t.Errorf("%s: got %q (%v), want no output", format, output, err)
+		}
+	}
+}
+
+func TestFormatErrors(t *testing.T) {
+
+	if _, err := Format("xml", testList(), Options{}); err == nil {
+		t.Errorf("got no error for an invalid format")
+	}
+	if _, err := Format("template", testList(), Options{}); err == nil {
+		t.Errorf("got no error for the template format without a template file")
+	}
+}
+
+// TestJSONSchema checks that the JSON output has the fields documented in the README, and that the optional fields are omitted when empty
+func TestJSONSchema(t *testing.T) {
+
+	output, err := Format("json", testList(), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var list struct {
+		Period  string                   `json:"period"`
+		Entries []map[string]interface{} `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		t.Fatal(err)
+	}
+
+	required := []string{"id", "date", "week", "status", "time", "started", "elapsed", "elapsedSeconds", "message"}
+	wantFields := [][]string{
+		append(required, "tags", "project"),
+		append(required, "tags"),
+		append(required, "history"),
+	}
+	for i, entry := range list.Entries {
+		var fields []string
+		for field := range entry {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		want := append([]string(nil), wantFields[i]...)
+		sort.Strings(want)
+		if !reflect.DeepEqual(fields, want) {
+			t.Errorf("entry %d has the fields %v, want %v", i, fields, want)
+		}
+	}
+
+	history, ok := list.Entries[2]["history"].([]interface{})
+	if !ok || len(history) != 2 {
+		t.Fatalf("history %v, want 2 revisions", list.Entries[2]["history"])
+	}
+	if revision, ok := history[0].(map[string]interface{}); !ok || revision["time"] != "2026-10-17T08:15:00Z" || revision["message"] != "Optimised database queries" {
+		t.Errorf("first revision %v, want the original message with its RFC 3339 time", history[0])
+	}
+}
+
+// TestCSVQuoting checks that messages with quotes, commas and line breaks are read back as they were
+func TestCSVQuoting(t *testing.T) {
+
+	list := testList()
+	output, err := Format("csv", list, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(output)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != len(list.Entries)+1 {
+		t.Fatalf("read %d records, want a header and %d entries", len(records), len(list.Entries))
+	}
+	for i, entry := range list.Entries {
+		if message := records[i+1][7]; message != entry.Message {
+			t.Errorf("read the message %q, want %q", message, entry.Message)
+		}
+	}
+}
+
+func TestMarkdownEscape(t *testing.T) {
+
+	tests := map[string]string{
+		"plain message":           "plain message",
+		"**bold** and _italic_":   `\*\*bold\*\* and \_italic\_`,
+		"[link](url) <b>":         `\[link\](url) \<b\>`,
+		"`code` with a \\":        "\\`code\\` with a \\\\",
+		"a | b":                   `a \| b`,
+		"first line\nsecond line": "first line second line",
+		"windows\r\nline":         "windows line",
+	}
+	for message, want := range tests {
+		if got := MarkdownEscape(message); got != want {
+			t.Errorf("MarkdownEscape(%q) = %q, want %q", message, got, want)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package outputManager
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// jsonFormatter formats the list output as JSON
+type jsonFormatter struct{}
+
+// Format formats the list output as JSON
+func (jsonFormatter) Format(list List, options Options) (string, error) {
+	var jsonReturn bytes.Buffer
+	jsonEncoder := json.NewEncoder(&jsonReturn)
+	jsonEncoder.SetEscapeHTML(false)
+	if err := jsonEncoder.Encode(list); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(jsonReturn.String()), nil
+}
@@ -0,0 +1,66 @@
+package outputManager
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// markdownFormatter formats the list output as Markdown grouped by day (I.e for standups)
+type markdownFormatter struct{}
+
+// Format formats the list output as Markdown with a heading for each day
+func (markdownFormatter) Format(list List, options Options) (string, error) {
+
+	if len(list.Entries) == 0 {
+		return "", nil
+	}
+
+	stdReturn := "# Worklog (" + list.Period + ")\n"
+
+	var currentDate string
+	for _, entry := range list.Entries {
+		if entry.Date != currentDate {
+			currentDate = entry.Date
+			heading := entry.Date
+			if date, err := time.Parse("2006-01-02", entry.Date); err == nil {
+				heading = date.Format("Monday, January 2, 2006")
+			}
+			stdReturn += "\n## " + heading + "\n\n"
+		}
+
+		var details []string
+		if entry.Status != "" {
+			details = append(details, entry.Status)
+		}
+		if entry.Elapsed != "" {
+			details = append(details, entry.Elapsed+" worked")
+		}
+
+		stdReturn += fmt.Sprintf("- **%s** %s %s", entry.ID, entry.Time, MarkdownEscape(entry.Message+metadataSuffix(entry)))
+		if len(details) > 0 {
+			stdReturn += " _(" + strings.Join(details, ", ") + ")_"
+		}
+		stdReturn += "\n"
+	}
+
+	return strings.TrimSpace(stdReturn), nil
+}
+
+// MarkdownEscape escapes the characters in a message that Markdown would otherwise format
+// Line breaks are joined with a space (Which is how Markdown renders them), so that a message stays in its list item or table cell
+func MarkdownEscape(message string) string {
+	return strings.NewReplacer(
+		"\r\n", " ",
+		"\n", " ",
+		`\`, `\\`,
+		"*", `\*`,
+		"_", `\_`,
+		"`", "\\`",
+		"[", `\[`,
+		"]", `\]`,
+		"<", `\<`,
+		">", `\>`,
+		"|", `\|`,
+	).Replace(message)
+}
@@ -0,0 +1,39 @@
+package outputManager
+
+import "time"
+
+// This file holds the structures of the list output
+
+// List is the structure of the list output
+type List struct {
+	Period  string  `json:"period" yaml:"period"`
+	Entries []Entry `json:"entries" yaml:"entries"`
+}
+
+// Entry is the structure of a single entry in the list output
+type Entry struct {
+	ID             string     `json:"id" yaml:"id"`                               // The entry ID (MMDD-N)
+	Date           string     `json:"date" yaml:"date"`                           // The day the entry was logged (YYYY-MM-DD)
+	Week           string     `json:"week" yaml:"week"`                           // The ISO week the entry was logged (YYYY-Www)
+	Status         string     `json:"status" yaml:"status"`                       // added, started, paused, resumed, completed or removed
+	Time           string     `json:"time" yaml:"time"`                           // The wall-clock time the entry was logged (I.e 14:32, settings.output.timeFormat)
+	Started        *time.Time `json:"started,omitempty" yaml:"started,omitempty"` // The time the entry was logged
+	Elapsed        string     `json:"elapsed" yaml:"elapsed"`                     // The time worked on the entry (I.e 1h25m, settings.output.durationFormat)
+	ElapsedSeconds int64      `json:"elapsedSeconds" yaml:"elapsedSeconds"`       // The time worked on the entry in seconds
+	Message        string     `json:"message" yaml:"message"`                     // The current message of the entry
+	Tags           []string   `json:"tags,omitempty" yaml:"tags,omitempty"`       // The tags of the entry (--tag and #tag)
+	Project        string     `json:"project,omitempty" yaml:"project,omitempty"` // The project of the entry (--project and @project)
+	History        []Revision `json:"history,omitempty" yaml:"history,omitempty"` // The revision chain of the entry, starting with the original message (--history)
+}
+
+// Revision is the structure of a revision in the list output
+type Revision struct {
+	Time    time.Time `json:"time" yaml:"time"`
+	Message string    `json:"message" yaml:"message"`
+}
+
+// Options holds the options for the formatters
+type Options struct {
+	// TemplateFile is the path to the Go template used by the template format
+	TemplateFile string
+}
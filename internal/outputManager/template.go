@@ -0,0 +1,37 @@
+package outputManager
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"text/template"
+)
+
+// templateFormatter formats the list output with a Go template (--template-file)
+type templateFormatter struct{}
+
+// Format executes the template file with the list output (I.e {{range .Entries}}{{.ID}}: {{.Message}}{{end}})
+func (templateFormatter) Format(list List, options Options) (string, error) {
+
+	if options.TemplateFile == "" {
+		return "", errors.New("the template output format requires a template file (--template-file)")
+	}
+
+	templateData, err := os.ReadFile(options.TemplateFile)
+	if err != nil {
+		return "", errors.New("error reading template file (" + options.TemplateFile + "): " + err.Error())
+	}
+
+	listTemplate, err := template.New(options.TemplateFile).Parse(string(templateData))
+	if err != nil {
+		return "", errors.New("error parsing template file (" + options.TemplateFile + "): " + err.Error())
+	}
+
+	var templateReturn bytes.Buffer
+	if err := listTemplate.Execute(&templateReturn, list); err != nil {
+		return "", errors.New("error executing template file (" + options.TemplateFile + "): " + err.Error())
+	}
+
+	return strings.TrimRight(templateReturn.String(), "\n"), nil
+}
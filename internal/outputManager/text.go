@@ -0,0 +1,73 @@
+package outputManager
+
+import (
+	"fmt"
+	"strings"
+)
+
+// textFormatter formats the list output as plain text
+type textFormatter struct{}
+
+// Format formats the list output as plain text
+func (textFormatter) Format(list List, options Options) (string, error) {
+
+	if len(list.Entries) == 0 {
+		return "", nil
+	}
+
+	// Entries from more than one day are grouped under their date
+	var multipleDates bool
+	for _, entry := range list.Entries {
+		if entry.Date != list.Entries[0].Date {
+			multipleDates = true
+			break
+		}
+	}
+
+	stdReturn := "Period: " + list.Period
+	stdReturn += "\nWorklog:\n"
+	var currentDate string
+	for _, entry := range list.Entries {
+		if multipleDates && entry.Date != currentDate {
+			currentDate = entry.Date
+			stdReturn += currentDate + ":\n"
+		}
+		details := []string{"[" + entry.ID + "]"}
+		if entry.Time != "" {
+			details = append(details, entry.Time)
+		}
+		if entry.Status == "removed" {
+			details = append(details, "(removed)")
+		}
+		stdReturn += fmt.Sprintf("- %s %s [%s]\n", strings.Join(details, " "), entry.Message+metadataSuffix(entry), entry.Elapsed)
+		if len(entry.History) > 1 {
+			for revisionIndex, revision := range entry.History {
+				stdReturn += fmt.Sprintf("    %d. (%s) %s\n", revisionIndex, revision.Time.Format("2006-01-02 15:04"), revision.Message)
+			}
+		}
+	}
+
+	return strings.TrimSpace(stdReturn), nil
+}
+
+// metadataSuffix returns the tags and project of an entry which aren't already inline in its message (I.e " #infra @billing")
+func metadataSuffix(entry Entry) string {
+
+	message := strings.ToLower(entry.Message)
+
+	var tokens []string
+	if entry.Project != "" && !strings.Contains(message, "@"+entry.Project) {
+		tokens = append(tokens, "@"+entry.Project)
+	}
+	for _, tag := range entry.Tags {
+		if !strings.Contains(message, "#"+tag) {
+			tokens = append(tokens, "#"+tag)
+		}
+	}
+
+	if len(tokens) == 0 {
+		return ""
+	}
+
+	return " " + strings.Join(tokens, " ")
+}
@@ -0,0 +1,19 @@
+package outputManager
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// yamlFormatter formats the list output as YAML
+type yamlFormatter struct{}
+
+// Format formats the list output as YAML
+func (yamlFormatter) Format(list List, options Options) (string, error) {
+	yamlReturn, err := yaml.Marshal(list)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(yamlReturn)), nil
+}
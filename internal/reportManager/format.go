@@ -0,0 +1,147 @@
+package reportManager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/outputManager"
+)
+
+// This file is used to format the reports
+
+var (
+	// Formats are the output formats of the reports
+	Formats = []string{"text", "markdown", "json"}
+)
+
+// Format formats the report in the output format
+func Format(format string, report Report) (string, error) {
+	switch strings.ToLower(format) {
+	case "text":
+		return formatText(report), nil
+	case "markdown", "md":
+		return formatMarkdown(report), nil
+	case "json":
+		var jsonReturn bytes.Buffer
+		jsonEncoder := json.NewEncoder(&jsonReturn)
+		jsonEncoder.SetEscapeHTML(false)
+		if err := jsonEncoder.Encode(report); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(jsonReturn.String()), nil
+	}
+	return "", errors.New("invalid output format (" + format + "), expected one of: " + strings.Join(Formats, ", "))
+}
+
+// streakText describes a streak (I.e 3 days (2026-03-02 to 2026-03-04))
+func streakText(streak Streak) string {
+	if streak.Days == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%s (%s to %s)", pluralize(streak.Days, "day"), streak.From, streak.To)
+}
+
+// pluralize returns the count with the plural of the noun unless there is one of it (I.e 1 day, 2 days)
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	if noun == "entry" {
+		return fmt.Sprintf("%d entries", count)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// formatText formats the report as plain text
+func formatText(report Report) string {
+
+	stdReturn := fmt.Sprintf("Report: %s (%s to %s)\n\n", report.Period, report.From, report.To)
+	stdReturn += fmt.Sprintf("Entries: %s on %d of %s\n", pluralize(report.Entries, "entry"), report.DaysLogged, pluralize(len(report.Days), "day"))
+	stdReturn += fmt.Sprintf("Tracked: %s\n", report.Tracked)
+	stdReturn += fmt.Sprintf("Longest streak: %s\n", streakText(report.LongestStreak))
+	stdReturn += fmt.Sprintf("Current streak: %s\n", streakText(report.CurrentStreak))
+
+	if len(report.BusiestDays) > 0 {
+		stdReturn += "\nBusiest days:\n"
+		for _, day := range report.BusiestDays {
+			stdReturn += fmt.Sprintf("  %s %-9s  %-11s %s\n", day.Date, day.Weekday, pluralize(day.Entries, "entry"), day.Tracked)
+		}
+	}
+
+	for _, groups := range []struct {
+		title  string
+		prefix string
+		totals []GroupTotal
+	}{
+		{"By tag", "#", report.Tags},
+		{"By project", "@", report.Projects},
+	} {
+		if len(groups.totals) == 0 {
+			continue
+		}
+		stdReturn += "\n" + groups.title + ":\n"
+		for _, total := range groups.totals {
+			stdReturn += fmt.Sprintf("  %-20s %-11s %s\n", groups.prefix+total.Name, pluralize(total.Entries, "entry"), total.Tracked)
+		}
+	}
+
+	stdReturn += "\nBy day:\n"
+	for _, day := range report.Days {
+		stdReturn += fmt.Sprintf("  %s %-9s  %-11s %s\n", day.Date, day.Weekday, pluralize(day.Entries, "entry"), day.Tracked)
+		for _, item := range day.Items {
+			stdReturn += fmt.Sprintf("    - [%s] %s %s [%s]\n", item.ID, item.Time, item.Message, item.Worked)
+		}
+	}
+
+	return strings.TrimSpace(stdReturn)
+}
+
+// formatMarkdown formats the report as Markdown (I.e for a weekly review)
+func formatMarkdown(report Report) string {
+
+	stdReturn := fmt.Sprintf("# Report: %s (%s to %s)\n\n", report.Period, report.From, report.To)
+	stdReturn += fmt.Sprintf("- **Entries:** %s on %d of %s\n", pluralize(report.Entries, "entry"), report.DaysLogged, pluralize(len(report.Days), "day"))
+	stdReturn += fmt.Sprintf("- **Tracked:** %s\n", report.Tracked)
+	stdReturn += fmt.Sprintf("- **Longest streak:** %s\n", streakText(report.LongestStreak))
+	stdReturn += fmt.Sprintf("- **Current streak:** %s\n", streakText(report.CurrentStreak))
+
+	if len(report.BusiestDays) > 0 {
+		stdReturn += "\n## Busiest days\n\n| Day | Entries | Tracked |\n| --- | ---: | ---: |\n"
+		for _, day := range report.BusiestDays {
+			stdReturn += fmt.Sprintf("| %s %s | %d | %s |\n", day.Weekday, day.Date, day.Entries, day.Tracked)
+		}
+	}
+
+	for _, groups := range []struct {
+		title  string
+		column string
+		totals []GroupTotal
+	}{
+		{"By tag", "Tag", report.Tags},
+		{"By project", "Project", report.Projects},
+	} {
+		if len(groups.totals) == 0 {
+			continue
+		}
+		stdReturn += fmt.Sprintf("\n## %s\n\n| %s | Entries | Tracked |\n| --- | ---: | ---: |\n", groups.title, groups.column)
+		for _, total := range groups.totals {
+			stdReturn += fmt.Sprintf("| %s | %d | %s |\n", outputManager.MarkdownEscape(total.Name), total.Entries, total.Tracked)
+		}
+	}
+
+	stdReturn += "\n## By day\n"
+	for _, day := range report.Days {
+		stdReturn += fmt.Sprintf("\n### %s %s (%s, %s)\n", day.Weekday, day.Date, pluralize(day.Entries, "entry"), day.Tracked)
+		if len(day.Items) > 0 {
+			stdReturn += "\n"
+		}
+		for _, item := range day.Items {
+			stdReturn += fmt.Sprintf("- **%s** %s %s _(%s)_\n", item.ID, item.Time, outputManager.MarkdownEscape(item.Message), item.Worked)
+		}
+	}
+
+	return strings.TrimSpace(stdReturn)
+}
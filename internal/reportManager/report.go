@@ -0,0 +1,160 @@
+package reportManager
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+)
+
+// This file is used to build the reports from the log entries
+
+const (
+	// busiestDaysLimit is how many of the busiest days are in a report
+	busiestDaysLimit = 3
+
+	// dateLayout is the layout of the dates in a report
+	dateLayout = "2006-01-02"
+)
+
+// Build builds the report of the entries in the period (Removed entries should already be left out)
+// The time worked is the elapsed time of the entries, which only counts the closed intervals and
+// a running interval until the end of the day it was started on (So an entry which was never ended doesn't grow the report)
+func Build(period string, entries []logManager.LogEntry) (Report, error) {
+
+	startDate, endDate, err := calendarManager.PeriodRange(period)
+	if err != nil {
+		return Report{}, err
+	}
+
+	report := Report{
+		Period: period,
+		From:   startDate.Format(dateLayout),
+		To:     endDate.Format(dateLayout),
+	}
+
+	// Group the entries by day
+	dayEntries := make(map[string][]logManager.LogEntry)
+	for _, entry := range entries {
+		dayEntries[entry.Date] = append(dayEntries[entry.Date], entry)
+	}
+
+	tags := make(map[string]*GroupTotal)
+	projects := make(map[string]*GroupTotal)
+
+	for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+		day := Day{
+			Date:    date.Format(dateLayout),
+			Weekday: date.Weekday().String(),
+			WorkDay: calendarManager.IsWorkDay(date.Weekday()),
+		}
+		for _, entry := range dayEntries[day.Date] {
+			day.Entries++
+			day.TrackedSeconds += entry.Elapsed
+			day.Items = append(day.Items, DayEntry{
+				ID:      entry.ID,
+				Time:    entry.Time,
+				Status:  entry.Status,
+				Worked:  entry.Worked,
+				Message: entry.Message,
+				Tags:    entry.Tags,
+				Project: entry.Project,
+			})
+			for _, tag := range entry.Tags {
+				addToGroup(tags, tag, entry.Elapsed)
+			}
+			if entry.Project != "" {
+				addToGroup(projects, entry.Project, entry.Elapsed)
+			}
+		}
+		day.Tracked = logManager.ConvertDuration(day.TrackedSeconds)
+
+		report.Entries += day.Entries
+		report.TrackedSeconds += day.TrackedSeconds
+		if day.Entries > 0 {
+			report.DaysLogged++
+		}
+		report.Days = append(report.Days, day)
+	}
+	report.Tracked = logManager.ConvertDuration(report.TrackedSeconds)
+
+	report.LongestStreak, report.CurrentStreak = streaks(report.Days, calendarManager.Now().Format(dateLayout))
+	report.BusiestDays = busiestDays(report.Days)
+	report.Tags = sortedGroups(tags)
+	report.Projects = sortedGroups(projects)
+
+	return report, nil
+}
+
+// addToGroup adds an entry to the total of its tag or project
+func addToGroup(groups map[string]*GroupTotal, name string, elapsed int64) {
+	if groups[name] == nil {
+		groups[name] = &GroupTotal{Name: name}
+	}
+	groups[name].Entries++
+	groups[name].TrackedSeconds += elapsed
+}
+
+// sortedGroups returns the totals with the most time worked first (Ties are sorted by name)
+func sortedGroups(groups map[string]*GroupTotal) []GroupTotal {
+	var sorted []GroupTotal
+	for _, group := range groups {
+		group.Tracked = logManager.ConvertDuration(group.TrackedSeconds)
+		sorted = append(sorted, *group)
+	}
+	slices.SortFunc(sorted, func(a, b GroupTotal) int {
+		if compared := cmp.Compare(b.TrackedSeconds, a.TrackedSeconds); compared != 0 {
+			return compared
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return sorted
+}
+
+// busiestDays returns the logged days with the most time worked (Ties are sorted by the number of entries and then by date)
+func busiestDays(days []Day) []Day {
+	var busiest []Day
+	for _, day := range days {
+		if day.Entries > 0 {
+			day.Items = nil
+			busiest = append(busiest, day)
+		}
+	}
+	slices.SortStableFunc(busiest, func(a, b Day) int {
+		if compared := cmp.Compare(b.TrackedSeconds, a.TrackedSeconds); compared != 0 {
+			return compared
+		}
+		return cmp.Compare(b.Entries, a.Entries)
+	})
+	if len(busiest) > busiestDaysLimit {
+		busiest = busiest[:busiestDaysLimit]
+	}
+	return busiest
+}
+
+// streaks returns the longest streak and the streak up to the end of the days
+// Days outside of the work week only extend a streak when they were logged, and today doesn't break a streak until it is over
+func streaks(days []Day, today string) (Streak, Streak) {
+
+	var longest, current Streak
+	for _, day := range days {
+		switch {
+		case day.Entries > 0:
+			if current.Days == 0 {
+				current.From = day.Date
+			}
+			current.Days++
+			current.To = day.Date
+			if current.Days > longest.Days {
+				longest = current
+			}
+		case !day.WorkDay, day.Date == today:
+			continue
+		default:
+			current = Streak{}
+		}
+	}
+
+	return longest, current
+}
@@ -0,0 +1,57 @@
+package reportManager
+
+// This file holds the structures of the reports
+
+// Report is the summary of the entries in a period
+type Report struct {
+	Period         string       `json:"period" yaml:"period"`                         // The period of the report (I.e week, 2026-03)
+	From           string       `json:"from" yaml:"from"`                             // The first day of the period (YYYY-MM-DD)
+	To             string       `json:"to" yaml:"to"`                                 // The last day of the period (YYYY-MM-DD)
+	Entries        int          `json:"entries" yaml:"entries"`                       // The number of entries in the period
+	Tracked        string       `json:"tracked" yaml:"tracked"`                       // The time worked in the period (I.e 14h30m, settings.output.durationFormat)
+	TrackedSeconds int64        `json:"trackedSeconds" yaml:"trackedSeconds"`         // The time worked in the period in seconds
+	DaysLogged     int          `json:"daysLogged" yaml:"daysLogged"`                 // The number of days with at least one entry
+	LongestStreak  Streak       `json:"longestStreak" yaml:"longestStreak"`           // The longest run of logged work days
+	CurrentStreak  Streak       `json:"currentStreak" yaml:"currentStreak"`           // The run of logged work days up to the end of the period
+	BusiestDays    []Day        `json:"busiestDays" yaml:"busiestDays"`               // The days with the most time worked (Without their entries)
+	Tags           []GroupTotal `json:"tags,omitempty" yaml:"tags,omitempty"`         // The totals of each tag, most time worked first
+	Projects       []GroupTotal `json:"projects,omitempty" yaml:"projects,omitempty"` // The totals of each project, most time worked first
+	Days           []Day        `json:"days" yaml:"days"`                             // Every day of the period
+}
+
+// Streak is a run of consecutive logged work days (Days outside of the work week don't break a streak)
+type Streak struct {
+	Days int    `json:"days" yaml:"days"`
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   string `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// GroupTotal is the total of the entries with a tag or project
+type GroupTotal struct {
+	Name           string `json:"name" yaml:"name"`
+	Entries        int    `json:"entries" yaml:"entries"`
+	Tracked        string `json:"tracked" yaml:"tracked"`
+	TrackedSeconds int64  `json:"trackedSeconds" yaml:"trackedSeconds"`
+}
+
+// Day is a single day of the report
+type Day struct {
+	Date           string     `json:"date" yaml:"date"`                       // The day (YYYY-MM-DD)
+	Weekday        string     `json:"weekday" yaml:"weekday"`                 // The day of the week (I.e Monday)
+	WorkDay        bool       `json:"workDay" yaml:"workDay"`                 // If the day is in the work week (settings.schedule.days)
+	Entries        int        `json:"entries" yaml:"entries"`                 // The number of entries on the day
+	Tracked        string     `json:"tracked" yaml:"tracked"`                 // The time worked on the day
+	TrackedSeconds int64      `json:"trackedSeconds" yaml:"trackedSeconds"`   // The time worked on the day in seconds
+	Items          []DayEntry `json:"items,omitempty" yaml:"items,omitempty"` // The entries of the day
+}
+
+// DayEntry is an entry in the day-by-day breakdown
+type DayEntry struct {
+	ID      string   `json:"id" yaml:"id"`
+	Time    string   `json:"time" yaml:"time"`
+	Status  string   `json:"status" yaml:"status"`
+	Worked  string   `json:"worked" yaml:"worked"`
+	Message string   `json:"message" yaml:"message"`
+	Tags    []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Project string   `json:"project,omitempty" yaml:"project,omitempty"`
+}
@@ -0,0 +1,84 @@
+package reportManager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestBuildRunningEntries checks that entries which were never ended only count until the end of the day they were started on
+func TestBuildRunningEntries(t *testing.T) {
+
+	testutil.LoadConfig(t, testutil.Options{})
+
+	setNow := func(day, hour int) {
+		testutil.PinClock(t, time.Date(2026, time.October, day, hour, 0, 0, 0, time.UTC))
+	}
+
+	action := func(action, logMessage, logId string) logManager.LogFileEntries {
+		t.Helper()
+		entries, _, err := logManager.Action(action, logMessage, logId, "", logManager.ActionOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return entries
+	}
+
+	// Never ended, so it counts from 14:00 until midnight
+	setNow(11, 14)
+	action("add", "never ended #support", "")
+
+	// Paused after an hour
+	setNow(16, 9)
+	action("add", "paused #support", "")
+	setNow(16, 10)
+	action("pause", "", "1016-1")
+
+	// Still running, so it counts until now
+	setNow(17, 13)
+	action("add", "running @worklog", "")
+	setNow(17, 15)
+
+	period := calendarManager.DateRangePeriod("2026-10-11", "2026-10-17")
+	listed, logIds, err := logManager.Action("list", "", "", period, logManager.ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []logManager.LogEntry
+	for _, logId := range logIds {
+		entries = append(entries, listed.Entries[logId])
+	}
+
+	report, err := Build(period, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := time.Duration(report.TrackedSeconds)*time.Second, 13*time.Hour; got != want {
+		t.Errorf("tracked %s, want %s", got, want)
+	}
+
+	wantDays := map[string]time.Duration{
+		"2026-10-11": 10 * time.Hour,
+		"2026-10-16": time.Hour,
+		"2026-10-17": 2 * time.Hour,
+	}
+	for _, day := range report.Days {
+		if got := time.Duration(day.TrackedSeconds) * time.Second; got != wantDays[day.Date] {
+			t.Errorf("tracked %s on %s, want %s", got, day.Date, wantDays[day.Date])
+		}
+	}
+
+	wantGroups := map[string]time.Duration{
+		"support": 11 * time.Hour,
+		"worklog": 2 * time.Hour,
+	}
+	for _, group := range append(report.Tags, report.Projects...) {
+		if got := time.Duration(group.TrackedSeconds) * time.Second; got != wantGroups[group.Name] {
+			t.Errorf("tracked %s on %s, want %s", got, group.Name, wantGroups[group.Name])
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package syncManager
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// This file is used to format the sync status
+
+var (
+	// StatusFormats are the output formats of the sync status
+	StatusFormats = []string{"text", "json"}
+)
+
+// FormatStatus formats the sync status in the output format
+// With dryRun, the text describes what worklog sync would do instead
+func FormatStatus(format string, status Status, dryRun bool) (string, error) {
+	switch strings.ToLower(format) {
+	case "text":
+		if dryRun {
+			return formatDryRun(status), nil
+		}
+		return formatStatusText(status), nil
+	case "json":
+		var jsonReturn bytes.Buffer
+		jsonEncoder := json.NewEncoder(&jsonReturn)
+		jsonEncoder.SetEscapeHTML(false)
+		jsonEncoder.SetIndent("", "  ")
+		if err := jsonEncoder.Encode(status); err != nil {
+			return "", err
+		}
+		return strings.TrimSpace(jsonReturn.String()), nil
+	}
+	return "", errors.New("invalid output format (" + format + "), expected one of: " + strings.Join(StatusFormats, ", "))
+}
+
+// pluralize returns the count with the plural of the noun unless there is one of it (I.e 1 commit, 2 commits)
+func pluralize(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// formatChanges lists the changed weeks with their entries and the other changed files
+func formatChanges(status Status, builder *strings.Builder) {
+	for _, week := range status.Weeks {
+		builder.WriteString("  " + week.Week + ": " + week.WeekChanges.String() + "\n")
+	}
+	for _, file := range status.Files {
+		builder.WriteString("  " + file + "\n")
+	}
+}
+
+// formatStatusText formats the sync status as plain text
+func formatStatusText(status Status) string {
+
+	var builder strings.Builder
+
+	if status.RemoteExists {
+		builder.WriteString(fmt.Sprintf("Remote: %s (%s ahead, %s behind)\n", status.Remote, pluralize(status.Ahead, "commit"), pluralize(status.Behind, "commit")))
+	} else {
+		builder.WriteString(fmt.Sprintf("Remote: %s (Not pushed yet, %s ahead)\n", status.Remote, pluralize(status.Ahead, "commit")))
+	}
+
+	if status.UpToDate() {
+		builder.WriteString("You're up to date!")
+		return builder.String()
+	}
+
+	builder.WriteString("Uncommitted: " + pluralize(len(status.Uncommitted), "file") + "\n")
+
+	if len(status.Weeks) > 0 || len(status.Files) > 0 {
+		builder.WriteString("Local changes:\n")
+		formatChanges(status, &builder)
+	}
+
+	return strings.TrimSpace(builder.String())
+}
+
+// formatDryRun describes what worklog sync would do
+func formatDryRun(status Status) string {
+
+	if status.UpToDate() {
+		return "Nothing to sync, you're up to date!"
+	}
+
+	var builder strings.Builder
+
+	if status.Behind > 0 {
+		builder.WriteString(fmt.Sprintf("Would pull %s from %s\n", pluralize(status.Behind, "commit"), status.Remote))
+	}
+
+	commits := status.Ahead
+	if len(status.Uncommitted) > 0 {
+		builder.WriteString(fmt.Sprintf("Would commit %s: %s\n", pluralize(len(status.Uncommitted), "file"), strings.Join(status.Uncommitted, ", ")))
+		commits++
+	}
+
+	if commits > 0 {
+		builder.WriteString(fmt.Sprintf("Would push %s to %s:\n", pluralize(commits, "commit"), status.Remote))
+		formatChanges(status, &builder)
+	}
+
+	return strings.TrimSpace(builder.String())
+}
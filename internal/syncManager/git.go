@@ -0,0 +1,126 @@
+package syncManager
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to run git in the logs path
+
+// runGit runs git in the logs path and returns its trimmed output
+// The error includes what git printed to stderr
+func runGit(args ...string) (string, error) {
+
+	log.Debug("Running git ", strings.Join(args, " "))
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = configuration.LogsPath
+	if NonInteractive {
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		cmd.Stdin = nil
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return strings.TrimSpace(stdout.String()), fmt.Errorf("%w: git %s: %s", ErrGitFailed, args[0], message)
+	}
+
+	return strings.TrimSpace(stdout.String()), nil
+}
+
+// IsRepository checks if the logs path is a git repository
+func IsRepository() bool {
+	_, err := os.Stat(filepath.Join(configuration.LogsPath, ".git"))
+	return err == nil
+}
+
+// changedFiles returns the paths of the files with local changes, which aren't committed yet (git status)
+func changedFiles() ([]string, error) {
+	output, err := runGit("status", "--porcelain", "--untracked-files=all", "--no-renames")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, nil
+	}
+	var files []string
+	for _, line := range strings.Split(output, "\n") {
+		// Each line is the status (XY) and the path (The output is trimmed, so the status can lose its leading space)
+		if _, file, ok := strings.Cut(strings.TrimSpace(line), " "); ok {
+			files = append(files, strings.Trim(strings.TrimSpace(file), `"`))
+		}
+	}
+	return files, nil
+}
+
+// syncTarget is the remote and branch which the logs path is synced with
+type syncTarget struct {
+	Remote string // The name of the remote (I.e origin)
+	Branch string // The branch on the remote (I.e main)
+}
+
+// remoteBranch returns the remote tracking branch (I.e origin/main)
+func (s syncTarget) remoteBranch() string {
+	return s.Remote + "/" + s.Branch
+}
+
+// getSyncTarget returns the remote and branch which the checked out branch tracks (Its upstream)
+// sync init and git clone set the upstream, so the branch and remote they were given are used by every sync after them
+// Without an upstream (I.e the push of sync init failed), the checked out branch is synced with settings.git.remote
+func getSyncTarget() (syncTarget, error) {
+
+	branch, err := runGit("symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return syncTarget{}, err
+	}
+
+	target := syncTarget{Remote: configuration.GitRemote, Branch: branch}
+
+	if remote, err := runGit("config", "--get", "branch."+branch+".remote"); err == nil && remote != "" && remote != "." {
+		target.Remote = remote
+	}
+	if merge, err := runGit("config", "--get", "branch."+branch+".merge"); err == nil && merge != "" {
+		target.Branch = strings.TrimPrefix(merge, "refs/heads/")
+	}
+
+	if target.Remote != configuration.GitRemote || target.Branch != configuration.GitBranch {
+		log.Debug("Syncing with " + target.remoteBranch() + " (The upstream of " + branch + "), instead of settings.git.remote and settings.git.branch")
+	}
+
+	return target, nil
+}
+
+// ensureRemote makes sure that the remote exists in the repository
+// If it doesn't and it is the configured remote, it is added with settings.git.uri
+func ensureRemote(remote string) error {
+
+	url, err := runGit("remote", "get-url", remote)
+	if err == nil {
+		if remote == configuration.GitRemote && configuration.GitUri != "" && url != configuration.GitUri {
+			log.Debug("The remote " + remote + " (" + url + ") doesn't match settings.git.uri (" + configuration.GitUri + "), using the remote")
+		}
+		return nil
+	}
+
+	if remote != configuration.GitRemote || configuration.GitUri == "" {
+		return fmt.Errorf("%w, the remote %s doesn't exist and settings.git.uri is not set", ErrNoRemote, remote)
+	}
+
+	log.Info("Adding the remote " + remote + ": " + configuration.GitUri)
+	_, err = runGit("remote", "add", remote, configuration.GitUri)
+	return err
+}
@@ -0,0 +1,111 @@
+package syncManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to initialize the logs path as a git repository
+
+// InitOptions holds the options to initialize the logs path
+type InitOptions struct {
+	Remote string // The git URI of the remote (Defaults to settings.git.uri)
+	Branch string // The branch to push to (Defaults to settings.git.branch)
+}
+
+// withDefaults fills the options which weren't set from the configuration
+func (o InitOptions) withDefaults() InitOptions {
+	if o.Remote == "" {
+		o.Remote = configuration.GitUri
+	}
+	if o.Branch == "" {
+		o.Branch = configuration.GitBranch
+	}
+	if o.Branch == "" {
+		o.Branch = "main"
+	}
+	return o
+}
+
+// Init initializes the logs path as a git repository, commits the logs and pushes them to the remote
+// The remote repository has to exist already (I.e created on GitHub), but it can be empty
+// It returns the hash of the initial commit
+func Init(options InitOptions) (string, error) {
+
+	options = options.withDefaults()
+
+	if options.Remote == "" {
+		return "", fmt.Errorf("%w, set settings.git.uri or use --remote", ErrNoRemote)
+	}
+
+	uri, err := configuration.ParseGitUri(options.Remote)
+	if err != nil {
+		return "", err
+	}
+
+	if IsRepository() {
+		return "", fmt.Errorf("%w (%s)", ErrAlreadyInitialized, configuration.LogsPath)
+	}
+
+	if err := os.MkdirAll(configuration.LogsPath, 0755); err != nil {
+		return "", errors.New("error creating logs path (" + configuration.LogsPath + "): " + err.Error())
+	}
+
+	// Lock the logs so that nothing is saved while they are committed
+	unlock, err := logManager.LockLogs()
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	log.Debug("Initializing git repository: ", configuration.LogsPath)
+	if _, err := runGit("init"); err != nil {
+		return "", err
+	}
+
+	// Point HEAD at the branch before the first commit, so that it doesn't depend on the default branch of git
+	if _, err := runGit("symbolic-ref", "HEAD", "refs/heads/"+options.Branch); err != nil {
+		return "", err
+	}
+
+	if _, err := runGit("remote", "add", configuration.GitRemote, options.Remote); err != nil {
+		return "", err
+	}
+
+	if err := registerMergeDriver(); err != nil {
+		return "", err
+	}
+
+	if _, err := runGit("add", "--all"); err != nil {
+		return "", err
+	}
+
+	// The logs can be empty, so allow an empty commit to create the branch
+	if _, err := runGit("commit", "--allow-empty", "-m", commitMessage()); err != nil {
+		return "", err
+	}
+
+	commitHash, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := runGit("push", "--set-upstream", configuration.GitRemote, options.Branch); err != nil {
+		return commitHash, fmt.Errorf("the repository was initialized, but the push failed (Make sure that the remote repository (%s) exists and then run worklog sync): %w", describeRepository(uri), err)
+	}
+
+	return commitHash, nil
+}
+
+// describeRepository describes where the remote repository is (I.e user/repo on github.com)
+func describeRepository(uri configuration.GitURI) string {
+	if uri.Local() {
+		return uri.Path
+	}
+	return uri.Repository() + " on " + uri.Host
+}
@@ -0,0 +1,63 @@
+package syncManager
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to register the merge driver of the week files, so that git merges them by entry
+
+// registerMergeDriver points git at worklog merge-driver for the week files
+// The driver is set in .git/config (It is the path of this executable, so it isn't committed)
+// and the week files are assigned to it in .gitattributes (Which is committed, so every machine uses it)
+func registerMergeDriver() error {
+
+	executable, err := os.Executable()
+	if err != nil {
+		return errors.New("error finding the worklog executable for the merge driver: " + err.Error())
+	}
+
+	// git runs the driver with sh, so quote the path in case it has spaces
+	driver := "'" + strings.ReplaceAll(filepath.ToSlash(executable), "'", `'\''`) + "' merge-driver %O %A %B %P"
+
+	if current, _ := runGit("config", "--get", "merge."+mergeDriverName+".driver"); current != driver {
+		log.Debug("Registering the merge driver: ", driver)
+		if _, err := runGit("config", "merge."+mergeDriverName+".name", mergeDriverDescription); err != nil {
+			return err
+		}
+		if _, err := runGit("config", "merge."+mergeDriverName+".driver", driver); err != nil {
+			return err
+		}
+	}
+
+	attributesPath := filepath.Join(configuration.LogsPath, ".gitattributes")
+
+	attributes, err := os.ReadFile(attributesPath)
+	if err != nil && !os.IsNotExist(err) {
+		return errors.New("error reading " + attributesPath + ": " + err.Error())
+	}
+
+	lines := strings.Split(strings.ReplaceAll(string(attributes), "\r\n", "\n"), "\n")
+	if slices.Contains(lines, mergeDriverAttributes) {
+		return nil
+	}
+
+	log.Debug("Adding the merge driver to ", attributesPath)
+
+	if len(attributes) > 0 && !strings.HasSuffix(string(attributes), "\n") {
+		attributes = append(attributes, '\n')
+	}
+	attributes = append(attributes, mergeDriverAttributes+"\n"...)
+
+	if err := os.WriteFile(attributesPath, attributes, 0644); err != nil {
+		return errors.New("error writing " + attributesPath + ": " + err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package syncManager
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to record the entries the merge driver renumbered, so that the sync can tell the user their IDs changed
+//
+// git runs the merge driver during the rebase and drops what it prints, so the driver appends
+// the renumbered entries to a log in .git which the sync reads once the rebase is done
+
+// Renumbered is an entry which was given a new ID while merging, because another machine added an entry with the same ID
+type Renumbered struct {
+	Week  string `json:"week"`  // The week file of the entry (YYYY/WW)
+	OldID string `json:"oldId"` // The ID the entry had before the sync
+	NewID string `json:"newId"` // The ID the entry has now
+}
+
+// renumberLogPath returns the path of the renumber log of the logs path
+func renumberLogPath() string {
+	return filepath.Join(configuration.LogsPath, ".git", renumberLogFile)
+}
+
+// RecordRenumbered appends the entries which were renumbered in the week file to the renumber log
+// The merge driver doesn't load the configuration, so the renumber log is found through git in the current directory (Where git runs the driver)
+func RecordRenumbered(week string, renumbered map[string]string) error {
+
+	if len(renumbered) == 0 {
+		return nil
+	}
+
+	output, err := exec.Command("git", "rev-parse", "--git-path", renumberLogFile).Output()
+	if err != nil {
+		return errors.New("error finding the renumber log: " + err.Error())
+	}
+	logPath := strings.TrimSpace(string(output))
+
+	var oldIds []string
+	for oldId := range renumbered {
+		oldIds = append(oldIds, oldId)
+	}
+	sort.Strings(oldIds)
+
+	var lines []byte
+	for _, oldId := range oldIds {
+		line, err := json.Marshal(Renumbered{Week: filepath.ToSlash(week), OldID: oldId, NewID: renumbered[oldId]})
+		if err != nil {
+			return errors.New("error marshaling the renumbered entry (" + oldId + "): " + err.Error())
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return errors.New("error opening the renumber log (" + logPath + "): " + err.Error())
+	}
+	defer logFile.Close()
+
+	if _, err := logFile.Write(lines); err != nil {
+		return errors.New("error writing the renumber log (" + logPath + "): " + err.Error())
+	}
+
+	return nil
+}
+
+// readRenumbered returns the entries in the renumber log, in the order they were renumbered
+func readRenumbered() ([]Renumbered, error) {
+
+	logFile, err := os.Open(renumberLogPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.New("error opening the renumber log (" + renumberLogPath() + "): " + err.Error())
+	}
+	defer logFile.Close()
+
+	var renumbered []Renumbered
+	scanner := bufio.NewScanner(logFile)
+	for scanner.Scan() {
+		var entry Renumbered
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			log.Warn("Skipping a line of the renumber log (", renumberLogPath(), "): ", err)
+			continue
+		}
+		renumbered = append(renumbered, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return renumbered, errors.New("error reading the renumber log (" + renumberLogPath() + "): " + err.Error())
+	}
+
+	return renumbered, nil
+}
+
+// clearRenumbered removes the renumber log (Before a rebase, and once it was read or the rebase was rolled back)
+func clearRenumbered() error {
+	if err := os.Remove(renumberLogPath()); err != nil && !os.IsNotExist(err) {
+		return errors.New("error removing the renumber log (" + renumberLogPath() + "): " + err.Error())
+	}
+	return nil
+}
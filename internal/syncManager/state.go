@@ -0,0 +1,200 @@
+package syncManager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to record the state of a sync, so that it can be rolled back when it fails or was interrupted
+
+// syncState holds what is needed to put the logs path back the way it was before the sync
+type syncState struct {
+	StartHead string `json:"startHead"`          // HEAD before the sync started
+	Snapshot  string `json:"snapshot,omitempty"` // The commit of the local changes (They are restored as uncommitted changes)
+	Step      string `json:"step"`               // The step the sync was on
+	Time      int64  `json:"time"`               // The epoch time the sync started
+}
+
+// syncStatePath returns the path of the sync state (Kept in .git, so that it is never committed)
+func syncStatePath() string {
+	return filepath.Join(configuration.LogsPath, ".git", syncStateFile)
+}
+
+// loadSyncState returns the state of an interrupted sync, or nil if there is none
+func loadSyncState() (*syncState, error) {
+
+	data, err := os.ReadFile(syncStatePath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.New("error reading the sync state (" + syncStatePath() + "): " + err.Error())
+	}
+
+	var state syncState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, errors.New("error parsing the sync state (" + syncStatePath() + "): " + err.Error())
+	}
+
+	return &state, nil
+}
+
+// save records the step the sync is on
+func (s *syncState) save(step string) error {
+
+	log.Debug("Sync step: ", step)
+	s.Step = step
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return errors.New("error marshaling the sync state: " + err.Error())
+	}
+
+	if err := os.WriteFile(syncStatePath(), data, 0644); err != nil {
+		return errors.New("error writing the sync state (" + syncStatePath() + "): " + err.Error())
+	}
+
+	return nil
+}
+
+// clear removes the sync state once the sync is done (or was rolled back)
+func (s *syncState) clear() error {
+	if err := os.Remove(syncStatePath()); err != nil && !os.IsNotExist(err) {
+		return errors.New("error removing the sync state (" + syncStatePath() + "): " + err.Error())
+	}
+	return nil
+}
+
+// inProgress checks if git stopped in the middle of an operation (I.e rebase-merge) which has to be aborted first
+func inProgress(operation string) bool {
+	_, err := os.Stat(filepath.Join(configuration.LogsPath, ".git", operation))
+	return err == nil
+}
+
+// abortInProgress aborts a rebase or merge which git stopped in the middle of and returns what was done
+func abortInProgress() ([]string, error) {
+
+	var actions []string
+
+	if inProgress("rebase-merge") || inProgress("rebase-apply") {
+		if _, err := runGit("rebase", "--abort"); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "Aborted the rebase")
+	}
+
+	if inProgress("MERGE_HEAD") {
+		if _, err := runGit("merge", "--abort"); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "Aborted the merge")
+	}
+
+	return actions, nil
+}
+
+// rollback puts the logs path back the way it was before the sync and returns what was done
+func (s *syncState) rollback() ([]string, error) {
+
+	actions, err := abortInProgress()
+	if err != nil {
+		return actions, err
+	}
+
+	head, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		return actions, err
+	}
+
+	// If the sync was interrupted right after committing, the commit of the local changes wasn't recorded yet
+	if s.Snapshot == "" && s.Step == stepCommit && head != s.StartHead {
+		s.Snapshot = head
+	}
+
+	// The snapshot holds the local changes, so reset to it first and then undo the commit while keeping the changes
+	restoreTo := s.StartHead
+	if s.Snapshot != "" {
+		restoreTo = s.Snapshot
+	}
+
+	if head != restoreTo {
+		if _, err := runGit("reset", "--hard", restoreTo); err != nil {
+			return actions, err
+		}
+	}
+
+	if s.Snapshot != "" {
+		if _, err := runGit("reset", "--mixed", s.StartHead); err != nil {
+			return actions, err
+		}
+		actions = append(actions, "Restored the local changes (They are no longer committed)")
+	}
+
+	if head != restoreTo || s.Snapshot != "" {
+		actions = append(actions, "Reset the logs path to "+shortHash(s.StartHead))
+	}
+
+	// The entries which were renumbered by the rebase have their old IDs again
+	if err := clearRenumbered(); err != nil {
+		return actions, err
+	}
+
+	return actions, s.clear()
+}
+
+// Abort rolls back an interrupted sync (I.e it was killed, or the rollback failed) and returns what was done
+func Abort() ([]string, error) {
+
+	if !IsRepository() {
+		return nil, fmt.Errorf("%w (%s)", ErrNotRepository, configuration.LogsPath)
+	}
+
+	// The rollback resets the logs path, so nothing may be saved while it runs
+	unlock, err := logManager.LockLogs()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	state, err := loadSyncState()
+	if err != nil {
+		return nil, err
+	}
+
+	if state != nil {
+		log.Info("Rolling back the sync which was interrupted while " + state.Step)
+		return state.rollback()
+	}
+
+	// A sync of an older version doesn't have a state, but it can still have left a rebase, merge or stash behind
+	actions, err := abortInProgress()
+	if err != nil {
+		return actions, err
+	}
+
+	if stashes, err := runGit("stash", "list"); err == nil && stashes != "" {
+		actions = append(actions, "Found "+fmt.Sprint(len(strings.Split(stashes, "\n")))+" stashed changes which an older sync may have left behind (See: git stash list)")
+	}
+
+	if len(actions) == 0 {
+		return nil, ErrNothingToAbort
+	}
+
+	return actions, nil
+}
+
+// shortHash shortens a commit hash for messages
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
@@ -0,0 +1,150 @@
+package syncManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to find out what a sync would do without changing anything
+
+// WeekStatus holds the entries of a week file which changed locally
+type WeekStatus struct {
+	Week string `json:"week"` // YYYY/WW
+	logManager.WeekChanges
+}
+
+// Status holds the difference between the logs path and the remote
+type Status struct {
+	Remote       string       `json:"remote"`       // The remote branch (I.e origin/main)
+	RemoteExists bool         `json:"remoteExists"` // The branch exists on the remote (It doesn't until the first push)
+	Ahead        int          `json:"ahead"`        // The number of local commits which aren't on the remote
+	Behind       int          `json:"behind"`       // The number of remote commits which aren't local
+	Uncommitted  []string     `json:"uncommitted"`  // The files with changes which aren't committed yet
+	Weeks        []WeekStatus `json:"weeks"`        // The week files which changed locally (Committed or not) and their entries
+	Files        []string     `json:"files"`        // The other files which changed locally
+}
+
+// UpToDate checks if sync has nothing to do
+func (s Status) UpToDate() bool {
+	return s.Ahead == 0 && s.Behind == 0 && len(s.Uncommitted) == 0
+}
+
+// GetStatus compares the logs path with the remote, fetching it first if fetch is set
+// The week files are compared entry by entry with the last commit they have in common
+func GetStatus(fetch bool) (Status, error) {
+
+	status := Status{
+		Uncommitted: []string{},
+		Weeks:       []WeekStatus{},
+		Files:       []string{},
+	}
+
+	if !configuration.GitSync {
+		return status, ErrSyncDisabled
+	}
+
+	if !IsRepository() {
+		return status, fmt.Errorf("%w (%s), run: worklog sync init", ErrNotRepository, configuration.LogsPath)
+	}
+
+	target, err := getSyncTarget()
+	if err != nil {
+		return status, err
+	}
+	status.Remote = target.remoteBranch()
+
+	if _, err := runGit("remote", "get-url", target.Remote); err != nil {
+		return status, fmt.Errorf("%w, the remote %s doesn't exist (It is added by worklog sync)", ErrNoRemote, target.Remote)
+	}
+
+	if fetch {
+		log.Debug("Fetching the remote ", target.Remote)
+		if _, err := runGit("fetch", target.Remote); err != nil {
+			return status, err
+		}
+	}
+
+	_, err = runGit("rev-parse", "--verify", "--quiet", target.remoteBranch())
+	status.RemoteExists = err == nil
+
+	// Without the branch on the remote, everything is local
+	base := emptyTree
+	if status.RemoteExists {
+		if status.Behind, err = countCommits("HEAD.." + target.remoteBranch()); err != nil {
+			return status, err
+		}
+		if status.Ahead, err = countCommits(target.remoteBranch() + "..HEAD"); err != nil {
+			return status, err
+		}
+		if base, err = runGit("merge-base", "HEAD", target.remoteBranch()); err != nil {
+			return status, err
+		}
+	} else if status.Ahead, err = countCommits("HEAD"); err != nil {
+		return status, err
+	}
+
+	uncommitted, err := changedFiles()
+	if err != nil {
+		return status, err
+	}
+	status.Uncommitted = append(status.Uncommitted, uncommitted...)
+
+	changed, err := changedSince(base)
+	if err != nil {
+		return status, err
+	}
+
+	for _, file := range changed {
+
+		if !logManager.IsWeekFile(file) {
+			status.Files = append(status.Files, file)
+			continue
+		}
+
+		// A week file which doesn't exist on one side is an empty week
+		oldData, _ := runGit("show", base+":"+file)
+		newData, err := os.ReadFile(filepath.Join(configuration.LogsPath, filepath.FromSlash(file)))
+		if err != nil && !os.IsNotExist(err) {
+			return status, errors.New("error reading week file (" + file + "): " + err.Error())
+		}
+
+		changes, err := logManager.DiffWeekFiles([]byte(oldData), newData)
+		if err != nil {
+			return status, fmt.Errorf("error comparing week file (%s): %w", file, err)
+		}
+
+		status.Weeks = append(status.Weeks, WeekStatus{Week: file, WeekChanges: changes})
+	}
+
+	return status, nil
+}
+
+// changedSince returns the files which changed in the working tree since the commit, including new files, sorted by path
+func changedSince(commit string) ([]string, error) {
+
+	var files []string
+
+	for _, args := range [][]string{
+		{"diff", "--name-only", "--no-renames", commit},
+		{"ls-files", "--others", "--exclude-standard"},
+	} {
+		output, err := runGit(args...)
+		if err != nil {
+			return nil, err
+		}
+		if output != "" {
+			files = append(files, strings.Split(output, "\n")...)
+		}
+	}
+
+	slices.Sort(files)
+	return slices.Compact(files), nil
+}
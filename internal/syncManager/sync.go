@@ -0,0 +1,215 @@
+package syncManager
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mitchs-dev/library-go/generator"
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// This file is used to sync the logs path with the remote
+
+// Result holds the outcome of a sync
+type Result struct {
+	UpToDate      bool         // Nothing needed to be synced
+	RemoteCommits int          // The number of commits pulled from the remote
+	LocalChanges  int          // The number of changed files which were committed
+	Commit        string       // The hash of the commit which was pushed
+	Renumbered    []Renumbered // The local entries which were given a new ID, because the remote has an entry with the same ID
+	RolledBack    []string     // What was done to roll back a sync which failed
+}
+
+// commitMessage returns the message of the sync commits
+func commitMessage() string {
+	return "SNAPSHOT: " + generator.StringTimestamp(configuration.ScheduleWorkdayTimezone)
+}
+
+// Sync commits the local changes, rebases them onto the remote and pushes them
+// Every step is recorded, so when one fails the logs path is rolled back to how it was before the sync
+// The logs are locked for the whole sync, so that an entry can't be saved while it is committed, rebased or rolled back (And lost by the reset)
+// With force, the local changes are force pushed over the remote
+func Sync(force bool) (Result, error) {
+
+	var result Result
+
+	if !configuration.GitSync {
+		return result, ErrSyncDisabled
+	}
+
+	if !IsRepository() {
+		return result, fmt.Errorf("%w (%s), run: worklog sync init", ErrNotRepository, configuration.LogsPath)
+	}
+
+	unlock, err := logManager.LockLogs()
+	if err != nil {
+		return result, err
+	}
+	defer unlock()
+
+	state, err := loadSyncState()
+	if err != nil {
+		return result, err
+	}
+	if state != nil {
+		return result, fmt.Errorf("%w (It stopped while %s), run: worklog sync --abort", ErrSyncInterrupted, state.Step)
+	}
+
+	target, err := getSyncTarget()
+	if err != nil {
+		return result, err
+	}
+
+	if err := ensureRemote(target.Remote); err != nil {
+		return result, err
+	}
+
+	// Week files which were changed on two machines are merged by entry instead of conflicting
+	if err := registerMergeDriver(); err != nil {
+		return result, err
+	}
+
+	startHead, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		return result, err
+	}
+
+	state = &syncState{StartHead: startHead, Time: time.Now().Unix()}
+
+	result, err = state.sync(target, force)
+	if err != nil {
+		log.Warn("Sync failed while " + state.Step + ", rolling back")
+		actions, rollbackErr := state.rollback()
+		result.RolledBack = actions
+		if rollbackErr != nil {
+			return result, fmt.Errorf("sync failed while %s and it couldn't be rolled back (%v), run: worklog sync --abort: %w", state.Step, rollbackErr, err)
+		}
+		return result, fmt.Errorf("sync failed while %s, the logs path was rolled back to how it was before the sync: %w", state.Step, err)
+	}
+
+	if err := clearRenumbered(); err != nil {
+		log.Warn(err)
+	}
+
+	return result, state.clear()
+}
+
+// sync runs the steps of the sync with the remote branch, recording each one before it runs
+func (s *syncState) sync(target syncTarget, force bool) (Result, error) {
+
+	var result Result
+
+	files, err := changedFiles()
+	if err != nil {
+		return result, err
+	}
+	result.LocalChanges = len(files)
+
+	if len(files) > 0 {
+		log.Info("Have " + fmt.Sprint(len(files)) + " local changes.")
+
+		if err := s.save(stepCommit); err != nil {
+			return result, err
+		}
+		if _, err := runGit("add", "--all"); err != nil {
+			return result, err
+		}
+		if _, err := runGit("commit", "-m", commitMessage()); err != nil {
+			return result, err
+		}
+		if s.Snapshot, err = runGit("rev-parse", "HEAD"); err != nil {
+			return result, err
+		}
+		if err := s.save(stepCommit); err != nil {
+			return result, err
+		}
+	}
+
+	if err := s.save(stepFetch); err != nil {
+		return result, err
+	}
+	log.Debug("Fetching the remote ", target.Remote)
+	if _, err := runGit("fetch", target.Remote); err != nil {
+		return result, err
+	}
+
+	// The branch doesn't exist on the remote yet if nothing was pushed to it (I.e the push of sync init failed)
+	_, err = runGit("rev-parse", "--verify", "--quiet", target.remoteBranch())
+	remoteExists := err == nil
+
+	aheadRange := "HEAD"
+	if remoteExists {
+		aheadRange = target.remoteBranch() + "..HEAD"
+
+		result.RemoteCommits, err = countCommits("HEAD.." + target.remoteBranch())
+		if err != nil {
+			return result, err
+		}
+
+		if result.RemoteCommits > 0 {
+			log.Info("Remote has " + fmt.Sprint(result.RemoteCommits) + " commits ahead of local")
+
+			if err := s.save(stepRebase); err != nil {
+				return result, err
+			}
+			if err := clearRenumbered(); err != nil {
+				return result, err
+			}
+			if _, err := runGit("rebase", target.remoteBranch()); err != nil {
+				return result, err
+			}
+			if result.Renumbered, err = readRenumbered(); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	localCommits, err := countCommits(aheadRange)
+	if err != nil {
+		return result, err
+	}
+
+	if localCommits == 0 && result.RemoteCommits == 0 {
+		result.UpToDate = true
+		return result, nil
+	}
+
+	if localCommits > 0 {
+		if err := s.save(stepPush); err != nil {
+			return result, err
+		}
+		pushArgs := []string{"push", target.Remote, "HEAD:refs/heads/" + target.Branch}
+		if !remoteExists {
+			pushArgs = append(pushArgs, "--set-upstream")
+		}
+		if force {
+			pushArgs = append(pushArgs, "--force")
+		}
+		if _, err := runGit(pushArgs...); err != nil {
+			return result, err
+		}
+	}
+
+	result.Commit, err = runGit("rev-parse", "HEAD")
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// countCommits returns the number of commits in the range (I.e HEAD..origin/main)
+func countCommits(commitRange string) (int, error) {
+	output, err := runGit("rev-list", "--count", commitRange)
+	if err != nil {
+		return 0, err
+	}
+	count, err := strconv.Atoi(output)
+	if err != nil {
+		return 0, fmt.Errorf("%w: unexpected commit count (%s)", ErrGitFailed, output)
+	}
+	return count, nil
+}
@@ -0,0 +1,51 @@
+package syncManager
+
+import "errors"
+
+// This file holds the variables associated with the sync manager
+
+// Sync variables
+var (
+	// NonInteractive stops git from prompting (I.e for credentials) so that sync fails instead of blocking (Set with --non-interactive)
+	NonInteractive bool
+)
+
+// Merge driver variables
+const (
+	// mergeDriverName is the name of the merge driver in .git/config and .gitattributes
+	mergeDriverName = "worklog"
+
+	// mergeDriverDescription is shown by git for the merge driver
+	mergeDriverDescription = "worklog week file merge driver"
+
+	// mergeDriverAttributes assigns the week files (YYYY/WW) to the merge driver in .gitattributes
+	mergeDriverAttributes = "[0-9][0-9][0-9][0-9]/[0-9][0-9] merge=" + mergeDriverName
+)
+
+// Sync steps (Recorded in the sync state, so that an interrupted sync can be rolled back)
+const (
+	// syncStateFile is the name of the sync state in .git
+	syncStateFile = "worklog-sync.json"
+
+	// renumberLogFile is the name of the log in .git of the entries the merge driver renumbered
+	renumberLogFile = "worklog-renumbered"
+
+	stepCommit = "committing the local changes"
+	stepFetch  = "fetching the remote"
+	stepRebase = "rebasing onto the remote"
+	stepPush   = "pushing to the remote"
+)
+
+// emptyTree is the hash of the tree without any files (What the logs path is compared to before the first push)
+const emptyTree = "4b825dc642cb6eb9a060e54bf8d69288fbee4904"
+
+// Errors returned by the sync manager (Check for them with errors.Is)
+var (
+	ErrSyncDisabled       = errors.New("git sync is not enabled in the configuration")
+	ErrNotRepository      = errors.New("the logs path is not a git repository")
+	ErrAlreadyInitialized = errors.New("the logs path is already a git repository")
+	ErrNoRemote           = errors.New("no git remote configured")
+	ErrGitFailed          = errors.New("git failed")
+	ErrSyncInterrupted    = errors.New("a previous sync was interrupted")
+	ErrNothingToAbort     = errors.New("there is no interrupted sync to abort")
+)
@@ -0,0 +1,416 @@
+package syncManager
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	"github.com/mitchs-dev/worklog/internal/testutil"
+)
+
+// TestMain lets the test binary act as the merge driver, since the merge driver is registered as the running executable
+// Every merge-driver call has to end here, otherwise git would run the tests again inside the sync
+func TestMain(m *testing.M) {
+
+	if len(os.Args) > 1 && os.Args[1] == "merge-driver" {
+		if len(os.Args) != 6 {
+			fmt.Fprintln(os.Stderr, "expected: merge-driver <base> <ours> <theirs> <path>")
+			os.Exit(1)
+		}
+		renumbered, err := logManager.MergeWeekFiles(os.Args[2], os.Args[3], os.Args[4])
+		if err == nil {
+			err = RecordRenumbered(os.Args[5], renumbered)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+// setupTestSync creates a bare remote and loads a configuration which syncs the logs path (dir/logs) to it
+// git is run without the global and system configuration, and the clock is pinned so that every entry is on the same day
+func setupTestSync(t *testing.T) (string, string) {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	remote := filepath.Join(t.TempDir(), "remote.git")
+	dir := testutil.LoadConfig(t, testutil.Options{GitUri: remote})
+
+	t.Setenv("HOME", dir)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_AUTHOR_NAME", "worklog")
+	t.Setenv("GIT_AUTHOR_EMAIL", "worklog@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "worklog")
+	t.Setenv("GIT_COMMITTER_EMAIL", "worklog@example.com")
+
+	runGitIn(t, dir, "init", "--bare", "-b", "main", remote)
+
+	testutil.PinClock(t, time.Date(2026, time.October, 17, 9, 0, 0, 0, time.UTC))
+
+	return dir, remote
+}
+
+// runGitIn runs git in the directory and returns its trimmed output
+func runGitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v: %s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// useLogsPath switches the logs path (I.e to the clone of another machine)
+func useLogsPath(t *testing.T, logsPath string) {
+	t.Helper()
+	configuration.LogsPath = logsPath
+}
+
+// addEntry adds an entry to the logs path and returns its log id
+func addEntry(t *testing.T, message string) string {
+	t.Helper()
+	_, logIds, err := logManager.Action("add", message, "", "", logManager.ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logIds[0]
+}
+
+// entryMessage returns the message of the entry in the logs path
+func entryMessage(t *testing.T, logId string) string {
+	t.Helper()
+	entries, _, err := logManager.Action("get", "", logId, "", logManager.ActionOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries.Entries[logId].Message
+}
+
+// initTestSync initializes the logs path (dir/logs) and pushes it to the remote
+func initTestSync(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir, remote := setupTestSync(t)
+
+	commit, err := Init(InitOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remoteHead := runGitIn(t, dir, "--git-dir", remote, "rev-parse", "main"); remoteHead != commit {
+		t.Fatalf("remote is at %s, want the initial commit %s", remoteHead, commit)
+	}
+
+	return dir, remote
+}
+
+func TestInitAndSync(t *testing.T) {
+
+	dir, remote := initTestSync(t)
+
+	addEntry(t, "first entry")
+
+	result, err := Sync(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.UpToDate || result.LocalChanges != 1 || result.RemoteCommits != 0 {
+		t.Errorf("got %+v, want 1 local change and no remote commits", result)
+	}
+	if remoteHead := runGitIn(t, dir, "--git-dir", remote, "rev-parse", "main"); remoteHead != result.Commit {
+		t.Errorf("remote is at %s, want the synced commit %s", remoteHead, result.Commit)
+	}
+
+	result, err = Sync(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.UpToDate {
+		t.Errorf("got %+v, want it to be up to date", result)
+	}
+}
+
+// TestSyncMergesEntriesAddedOnTwoMachines adds an entry with the same log id on two clones, so the week file conflicts and is merged by the merge driver
+func TestSyncMergesEntriesAddedOnTwoMachines(t *testing.T) {
+
+	dir, remote := initTestSync(t)
+	logsPathA := configuration.LogsPath
+	logsPathB := filepath.Join(dir, "b")
+	runGitIn(t, dir, "clone", remote, logsPathB)
+
+	if logId := addEntry(t, "from A"); logId != "1017-1" {
+		t.Fatalf("A added %s, want 1017-1", logId)
+	}
+	useLogsPath(t, logsPathB)
+	if logId := addEntry(t, "from B"); logId != "1017-1" {
+		t.Fatalf("B added %s, want 1017-1", logId)
+	}
+
+	useLogsPath(t, logsPathA)
+	if _, err := Sync(false); err != nil {
+		t.Fatal(err)
+	}
+
+	useLogsPath(t, logsPathB)
+	result, err := Sync(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.RemoteCommits != 1 {
+		t.Errorf("B pulled %d commits, want 1", result.RemoteCommits)
+	}
+	wantRenumbered := []Renumbered{{Week: "2026/42", OldID: "1017-1", NewID: "1017-2"}}
+	if !reflect.DeepEqual(result.Renumbered, wantRenumbered) {
+		t.Errorf("renumbered %+v, want %+v", result.Renumbered, wantRenumbered)
+	}
+	if _, err := os.Stat(renumberLogPath()); !os.IsNotExist(err) {
+		t.Errorf("the renumber log was left behind")
+	}
+
+	useLogsPath(t, logsPathA)
+	if _, err := Sync(false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, logsPath := range []string{logsPathA, logsPathB} {
+		useLogsPath(t, logsPath)
+		for logId, want := range map[string]string{"1017-1": "from A", "1017-2": "from B"} {
+			if got := entryMessage(t, logId); got != want {
+				t.Errorf("%s: %s = %q, want %q", filepath.Base(logsPath), logId, got, want)
+			}
+		}
+	}
+}
+
+// checkRolledBack checks that the logs path is back at the commit before the sync with the local changes uncommitted
+func checkRolledBack(t *testing.T, result Result, err error, startHead string) {
+	t.Helper()
+
+	if !errors.Is(err, ErrGitFailed) {
+		t.Fatalf("got %v, want a git error", err)
+	}
+	if !slices.Contains(result.RolledBack, "Restored the local changes (They are no longer committed)") {
+		t.Errorf("rolled back %q, want the local changes to be restored", result.RolledBack)
+	}
+
+	if head, err := runGit("rev-parse", "HEAD"); err != nil || head != startHead {
+		t.Errorf("HEAD is %s (%v), want %s", head, err, startHead)
+	}
+	if files, err := changedFiles(); err != nil || len(files) != 1 {
+		t.Errorf("changed files %v (%v), want the week file to be uncommitted again", files, err)
+	}
+	if state, err := loadSyncState(); err != nil || state != nil {
+		t.Errorf("sync state %+v (%v), want it to be cleared", state, err)
+	}
+	if entryMessage(t, "1017-1") != "not synced" {
+		t.Errorf("the entry was lost by the rollback")
+	}
+}
+
+func TestSyncRollsBackFailedFetch(t *testing.T) {
+
+	_, remote := initTestSync(t)
+
+	addEntry(t, "not synced")
+	startHead, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.RemoveAll(remote); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Sync(false)
+	if err == nil || !strings.Contains(err.Error(), stepFetch) {
+		t.Fatalf("got %v, want it to fail while %s", err, stepFetch)
+	}
+	checkRolledBack(t, result, err, startHead)
+}
+
+func TestSyncRollsBackFailedPush(t *testing.T) {
+
+	_, remote := initTestSync(t)
+
+	addEntry(t, "not synced")
+	startHead, err := runGit("rev-parse", "HEAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook := filepath.Join(remote, "hooks", "pre-receive")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\necho rejected >&2\nexit 1\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Sync(false)
+	if err == nil || !strings.Contains(err.Error(), stepPush) {
+		t.Fatalf("got %v, want it to fail while %s", err, stepPush)
+	}
+	checkRolledBack(t, result, err, startHead)
+}
+
+// TestSyncWaitsForTheLogsLock checks that sync doesn't run while another command is changing the logs
+func TestSyncWaitsForTheLogsLock(t *testing.T) {
+
+	initTestSync(t)
+
+	unlock, err := logManager.LockLogs()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unlock()
+
+	lockTimeout := logManager.LockTimeout
+	logManager.LockTimeout = 100 * time.Millisecond
+	t.Cleanup(func() { logManager.LockTimeout = lockTimeout })
+
+	if _, err := Sync(false); !errors.Is(err, logManager.ErrLockTimeout) {
+		t.Errorf("got %v, want it to time out waiting for the lock", err)
+	}
+}
+
+// TestInitWithBranchAndSync checks that sync uses the branch which sync init pushed to, instead of settings.git.branch
+func TestInitWithBranchAndSync(t *testing.T) {
+
+	dir, remote := setupTestSync(t)
+
+	if _, err := Init(InitOptions{Branch: "dev"}); err != nil {
+		t.Fatal(err)
+	}
+
+	addEntry(t, "on dev")
+
+	result, err := Sync(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remoteHead := runGitIn(t, dir, "--git-dir", remote, "rev-parse", "dev"); remoteHead != result.Commit {
+		t.Errorf("remote dev is at %s, want the synced commit %s", remoteHead, result.Commit)
+	}
+
+	status, err := GetStatus(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.Remote != "origin/dev" {
+		t.Errorf("status of %s, want origin/dev", status.Remote)
+	}
+}
+
+// TestStatus checks what sync status reports for local changes, local commits and commits on the remote
+func TestStatus(t *testing.T) {
+
+	dir, remote := initTestSync(t)
+	logsPathA := configuration.LogsPath
+	logsPathB := filepath.Join(dir, "b")
+
+	getStatus := func(fetch bool) Status {
+		t.Helper()
+		status, err := GetStatus(fetch)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return status
+	}
+
+	status := getStatus(true)
+	if !status.UpToDate() || !status.RemoteExists || status.Remote != "origin/main" {
+		t.Errorf("got %+v, want it to be up to date with origin/main", status)
+	}
+
+	// An entry which isn't committed yet
+	addEntry(t, "first entry")
+	status = getStatus(true)
+	wantWeeks := []WeekStatus{{Week: "2026/42", WeekChanges: logManager.WeekChanges{Added: []string{"1017-1"}}}}
+	if status.UpToDate() || status.Ahead != 0 || !reflect.DeepEqual(status.Uncommitted, []string{"2026/42"}) || !reflect.DeepEqual(status.Weeks, wantWeeks) {
+		t.Errorf("got %+v, want 2026/42 to be uncommitted with 1017-1 added", status)
+	}
+
+	if _, err := Sync(false); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another machine adds an entry, which is only seen once the remote is fetched
+	runGitIn(t, dir, "clone", remote, logsPathB)
+	useLogsPath(t, logsPathB)
+	addEntry(t, "from B")
+	if _, err := Sync(false); err != nil {
+		t.Fatal(err)
+	}
+	useLogsPath(t, logsPathA)
+
+	if status = getStatus(false); !status.UpToDate() {
+		t.Errorf("got %+v without fetching, want it to be up to date", status)
+	}
+	if status = getStatus(true); status.Behind != 1 || status.Ahead != 0 || len(status.Weeks) != 0 {
+		t.Errorf("got %+v, want it to be 1 commit behind without local changes", status)
+	}
+
+	// A local commit with an edit, and a file which isn't a week file
+	if _, _, err := logManager.Action("edit", "first entry, edited", "1017-1", "", logManager.ActionOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(logsPathA, "notes.txt"), []byte("notes"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGitIn(t, logsPathA, "add", "2026/42")
+	runGitIn(t, logsPathA, "commit", "-m", "Edit 1017-1")
+
+	status = getStatus(true)
+	wantWeeks = []WeekStatus{{Week: "2026/42", WeekChanges: logManager.WeekChanges{Changed: []string{"1017-1"}}}}
+	if status.Ahead != 1 || status.Behind != 1 || !reflect.DeepEqual(status.Uncommitted, []string{"notes.txt"}) {
+		t.Errorf("got %+v, want it to be 1 commit ahead and behind with notes.txt uncommitted", status)
+	}
+	if !reflect.DeepEqual(status.Weeks, wantWeeks) || !reflect.DeepEqual(status.Files, []string{"notes.txt"}) {
+		t.Errorf("weeks %+v and files %v, want 1017-1 changed and notes.txt", status.Weeks, status.Files)
+	}
+}
+
+// TestStatusBeforeTheFirstPush checks that everything is local while the branch doesn't exist on the remote
+func TestStatusBeforeTheFirstPush(t *testing.T) {
+
+	_, remote := initTestSync(t)
+
+	runGitIn(t, remote, "update-ref", "-d", "refs/heads/main")
+	runGitIn(t, configuration.LogsPath, "update-ref", "-d", "refs/remotes/origin/main")
+
+	status, err := GetStatus(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status.RemoteExists || status.Ahead != 1 || status.Behind != 0 {
+		t.Errorf("got %+v, want the initial commit to be ahead of a missing remote branch", status)
+	}
+}
+
+func TestStatusWithoutARepository(t *testing.T) {
+
+	setupTestSync(t)
+
+	if _, err := GetStatus(false); !errors.Is(err, ErrNotRepository) {
+		t.Errorf("got %v, want %v", err, ErrNotRepository)
+	}
+
+	configuration.GitSync = false
+	if _, err := GetStatus(false); !errors.Is(err, ErrSyncDisabled) {
+		t.Errorf("got %v, want %v", err, ErrSyncDisabled)
+	}
+}
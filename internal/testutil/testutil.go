@@ -0,0 +1,62 @@
+// Package testutil holds the fixtures which are shared by the tests of the internal packages
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/configuration"
+)
+
+// Options holds the settings of the test configuration which differ between tests
+type Options struct {
+	GitUri    string // The remote to sync the logs path with (Sync is enabled when it is set)
+	GitBranch string // The branch to sync with (Defaults to main)
+}
+
+// LoadConfig writes a configuration to a temporary directory and loads it
+// The logs path is logs in the temporary directory and the workday is disabled, everything else is the default configuration
+// It returns the temporary directory
+func LoadConfig(t *testing.T, options Options) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	configurationPath := filepath.Join(dir, "config")
+
+	configurationData := "settings:\n" +
+		"  logs:\n" +
+		"    path: " + filepath.Join(dir, "logs") + "\n" +
+		"  schedule:\n" +
+		"    workday:\n" +
+		"      enabled: false\n"
+	if options.GitUri != "" {
+		if options.GitBranch == "" {
+			options.GitBranch = "main"
+		}
+		configurationData += "  git:\n" +
+			"    sync: true\n" +
+			"    uri: " + options.GitUri + "\n" +
+			"    branch: " + options.GitBranch + "\n"
+	}
+
+	if err := os.WriteFile(configurationPath, []byte(configurationData), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	configuration.ConfigurationPath = configurationPath
+	if err := configuration.ConfigInit(); err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+// PinClock pins the clock to now in UTC until the test is done (It can be called again to move the clock)
+func PinClock(t *testing.T, now time.Time) {
+	t.Helper()
+	calendarManager.SetClock(func() time.Time { return now }, time.UTC)
+	t.Cleanup(func() { calendarManager.SetClock(nil, nil) })
+}
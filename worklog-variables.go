@@ -0,0 +1,43 @@
+package worklog
+
+import (
+	"github.com/mitchs-dev/worklog/internal/calendarManager"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+)
+
+// This file holds the variables of the worklog API
+
+// Entry statuses
+var (
+	EntryStatusAdded     = logManager.EntryStatusAdded
+	EntryStatusStarted   = logManager.EntryStatusStarted
+	EntryStatusPaused    = logManager.EntryStatusPaused
+	EntryStatusResumed   = logManager.EntryStatusResumed
+	EntryStatusCompleted = logManager.EntryStatusCompleted
+	EntryStatusRemoved   = logManager.EntryStatusRemoved
+)
+
+// SortOrders are the orders that entries can be sorted in
+var SortOrders = logManager.SortOrders
+
+// SearchModes are the ways a search query can be matched
+var SearchModes = logManager.SearchModes
+
+// Errors returned by the store (Check for them with errors.Is)
+var (
+	ErrInvalidPeriod       = calendarManager.ErrInvalidPeriod
+	ErrInvalidLogId        = logManager.ErrInvalidLogId
+	ErrEntryNotFound       = logManager.ErrEntryNotFound
+	ErrEntryRemoved        = logManager.ErrEntryRemoved
+	ErrEntryNotRemoved     = logManager.ErrEntryNotRemoved
+	ErrRestoreWindowPassed = logManager.ErrRestoreWindowPassed
+	ErrInvalidTransition   = logManager.ErrInvalidTransition
+	ErrEmptyMessage        = logManager.ErrEmptyMessage
+	ErrUnchangedMessage    = logManager.ErrUnchangedMessage
+	ErrInvalidMetadata     = logManager.ErrInvalidMetadata
+	ErrInvalidQuery        = logManager.ErrInvalidQuery
+	ErrIndexDisabled       = logManager.ErrIndexDisabled
+	ErrOutsideWorkday      = logManager.ErrOutsideWorkday
+	ErrCorruptLogFile      = logManager.ErrCorruptLogFile
+	ErrLockTimeout         = logManager.ErrLockTimeout
+)
@@ -0,0 +1,236 @@
+/*
+Copyright © 2025 @mitchs-dev <github@mitchs.dev>
+*/
+
+// Package worklog is the Go API of worklog, so that the worklog can be used from other tools
+//
+// The configuration is loaded into package variables, so only one store should be opened per process
+package worklog
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/mitchs-dev/worklog/internal/configuration"
+	"github.com/mitchs-dev/worklog/internal/logManager"
+	"github.com/mitchs-dev/worklog/internal/reportManager"
+	log "github.com/sirupsen/logrus"
+)
+
+// Entry is an entry in the worklog
+type Entry = logManager.LogEntry
+
+// Revision is a revision of the message of an entry
+type Revision = logManager.Revision
+
+// Metadata is the tags and project of an entry
+type Metadata = logManager.Metadata
+
+// Report is the summary of the entries in a period
+type Report = reportManager.Report
+
+// SearchQuery is what to search for in the messages of the entries
+type SearchQuery = logManager.SearchQuery
+
+// Store reads and writes the entries in the logs path of the configuration
+type Store struct {
+	// Override is the reason for adding, starting or resuming entries outside of the workday
+	// When it is empty, the workday is enforced as configured (settings.schedule.workday.enforcement)
+	Override string
+}
+
+// Open loads the configuration file and returns a store for its logs path
+// An empty configuration path uses (and creates if needed) the default configuration file
+func Open(configurationPath string) (*Store, error) {
+
+	configuration.ConfigurationPath = configurationPath
+
+	err := configuration.ConfigInit()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{}, nil
+}
+
+// Add adds a new entry with the message and starts it
+// The inline #tag and @project tokens in the message are added as metadata
+func (s *Store) Add(message string) (Entry, error) {
+	return s.AddWithMetadata(message, Metadata{})
+}
+
+// AddWithMetadata adds a new entry with tags and a project on top of the inline tokens in the message
+// The project takes precedence over an inline @project token
+func (s *Store) AddWithMetadata(message string, metadata Metadata) (Entry, error) {
+	return s.action("add", message, "", metadata)
+}
+
+// Get returns the entry for the log id (I.e 0123-4)
+func (s *Store) Get(logId string) (Entry, error) {
+	return s.action("get", "", logId, Metadata{})
+}
+
+// Edit adds a revision with the new message to the entry
+func (s *Store) Edit(logId, message string) (Entry, error) {
+	return s.action("edit", message, logId, Metadata{})
+}
+
+// Remove marks the entry as removed so that it can still be restored within the restore window
+func (s *Store) Remove(logId string) (Entry, error) {
+	return s.action("remove", "", logId, Metadata{})
+}
+
+// Restore restores a removed entry
+func (s *Store) Restore(logId string) (Entry, error) {
+	return s.action("restore", "", logId, Metadata{})
+}
+
+// Start starts the clock of an entry which was logged before worklog tracked time (Started entries are rejected with ErrInvalidTransition)
+func (s *Store) Start(logId string) (Entry, error) {
+	return s.action("start", "", logId, Metadata{})
+}
+
+// Pause pauses the clock of the entry
+func (s *Store) Pause(logId string) (Entry, error) {
+	return s.action("pause", "", logId, Metadata{})
+}
+
+// Resume resumes the clock of a paused entry
+func (s *Store) Resume(logId string) (Entry, error) {
+	return s.action("resume", "", logId, Metadata{})
+}
+
+// End completes the entry
+func (s *Store) End(logId string) (Entry, error) {
+	return s.action("end", "", logId, Metadata{})
+}
+
+// List returns the entries in the period (I.e today, cweek, 2026-W12, 2026-03-01..2026-03-31) ordered by log id
+// Removed entries are included with the removed status
+func (s *Store) List(period string) ([]Entry, error) {
+
+	logEntries, logIds, err := logManager.Action("list", "", "", period, logManager.ActionOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(logIds))
+	for _, logId := range logIds {
+		entries = append(entries, logEntries.Entries[logId])
+	}
+
+	return entries, nil
+}
+
+// Search returns the entries from the entire history whose message matches the query, ordered by date and then by log id
+// Removed entries are included with the removed status
+func (s *Store) Search(query SearchQuery) ([]Entry, error) {
+
+	logEntries, logIds, err := logManager.Search(query)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]Entry, 0, len(logIds))
+	for _, logId := range logIds {
+		entries = append(entries, logEntries.Entries[logId])
+	}
+
+	return entries, nil
+}
+
+// Report summarizes the entries in the period (I.e week, month, 2026-Q1), leaving out removed entries
+func (s *Store) Report(period string) (Report, error) {
+
+	entries, err := s.List(period)
+	if err != nil {
+		return Report{}, err
+	}
+
+	var reportEntries []Entry
+	for _, entry := range entries {
+		if entry.Status != EntryStatusRemoved {
+			reportEntries = append(reportEntries, entry)
+		}
+	}
+
+	return reportManager.Build(period, reportEntries)
+}
+
+// Reindex rebuilds the index of the logs path and returns the number of weeks and entries indexed
+func (s *Store) Reindex() (int, int, error) {
+	return logManager.Reindex()
+}
+
+// GarbageCollect removes the week files without any entries and returns their weeks (YYYY/WW)
+// With dryRun, the weeks which would be removed are returned without removing them
+func (s *Store) GarbageCollect(dryRun bool) ([]string, error) {
+	return logManager.GarbageCollect(dryRun)
+}
+
+// MergeWeekFiles merges two versions of a week file which were changed from the same base, the way git calls a merge driver (%O %A %B)
+// The merged week is written to the ours file, and the log ids of their entries which were renumbered are returned (Old ID -> New ID)
+// It doesn't need a store, since git runs it on files outside of the logs path
+func MergeWeekFiles(basePath, oursPath, theirsPath string) (map[string]string, error) {
+	return logManager.MergeWeekFiles(basePath, oursPath, theirsPath)
+}
+
+// FilterEntries returns the entries which have all of the tags and the project (An empty project matches any project)
+func FilterEntries(entries []Entry, tags []string, project string) []Entry {
+
+	var filtered []Entry
+	for _, entry := range entries {
+		if entry.HasMetadata(tags, project) {
+			filtered = append(filtered, entry)
+		}
+	}
+
+	return filtered
+}
+
+// SortEntries sorts the entries by one of the SortOrders (An empty sort order sorts by log id)
+func SortEntries(entries []Entry, sortOrder string, reverse bool) ([]Entry, error) {
+
+	logEntries := logManager.LogFileEntries{
+		Entries: make(map[string]Entry, len(entries)),
+	}
+	logIds := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		// Log ids repeat every year, so the date keeps them apart
+		key := entry.Date + "/" + entry.ID
+		logEntries.Entries[key] = entry
+		logIds = append(logIds, key)
+	}
+
+	logIds, err := logManager.SortLogIds(logEntries, logIds, sortOrder, reverse)
+	if err != nil {
+		return nil, err
+	}
+
+	sorted := make([]Entry, 0, len(logIds))
+	for _, logId := range logIds {
+		sorted = append(sorted, logEntries.Entries[logId])
+	}
+
+	return sorted, nil
+}
+
+// action runs an action against a single entry and returns the entry
+// The metadata and override are passed with the action, so that stores used from several goroutines don't share them
+func (s *Store) action(action, message, logId string, metadata Metadata) (Entry, error) {
+
+	log.Debug("Calling logManager.Action(\"" + action + "\")")
+	entries, logIds, err := logManager.Action(action, message, logId, "", logManager.ActionOptions{
+		Metadata: metadata,
+		Override: s.Override,
+	})
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if len(logIds) != 1 {
+		return Entry{}, errors.New("expected one entry from " + action + " but got " + fmt.Sprint(len(logIds)))
+	}
+
+	return entries.Entries[logIds[0]], nil
+}